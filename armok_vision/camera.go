@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -15,12 +16,18 @@ var (
 	viewLock     sync.Mutex
 )
 
-func UpdateViewInfo(conn *dfhack.Conn) {
-	info, _, err := conn.GetViewInfo()
+func UpdateViewInfo(ctx context.Context, conn *dfhack.Conn) error {
+	info, _, err := conn.GetViewInfoContext(ctx)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
+	SetViewInfo(info)
+
+	return nil
+}
+
+func SetViewInfo(info *RemoteFortressReader.ViewInfo) {
 	viewLock.Lock()
 	viewInfo = info
 	viewLock.Unlock()
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log"
 	"math"
+	"time"
 
 	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/respcache"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
@@ -52,34 +57,142 @@ func main() {
 	}
 }
 
+// StaticCache keeps the material and tiletype lists and the like across
+// reconnects, as long as the same world is loaded.
+var StaticCache = respcache.New()
+
 func Network(stop chan chan struct{}) {
-	var ch chan struct{}
-	defer func() {
-		if ch != nil {
-			close(ch)
-		}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ch := <-stop
+		cancel()
+		<-done
+		close(ch)
 	}()
 
-	conn, err := dfhack.Connect()
+	// Signalled whenever we (re)connect so we know to reload everything
+	// that depends on the loaded world.
+	connected := make(chan struct{}, 1)
+	// Signalled whenever the connection is lost, which ends any
+	// subscriptions.
+	disconnected := make(chan struct{}, 1)
+
+	conn, err := (&dfhack.Dialer{
+		Reconnect: true,
+		Interceptors: []dfhack.Interceptor{
+			StaticCache.Intercept,
+		},
+		StateChanged: func(state dfhack.ConnState, err error) {
+			if err != nil {
+				log.Println("dfhack:", state, err)
+			} else {
+				log.Println("dfhack:", state)
+			}
+			signal := connected
+			if state != dfhack.StateConnected {
+				signal = disconnected
+			}
+			select {
+			case signal <- struct{}{}:
+			default:
+			}
+		},
+	}).DialContext(ctx, "")
 	if err != nil {
-		panic(err)
+		return
 	}
 	defer conn.Close()
 
-	InitTiletypes(conn)
-	InitMaterials(conn)
-	InitMap(conn)
+	// Try to have updates pushed to us rather than asking for them over
+	// and over. Only armok_web can push, so this is checked again on
+	// every connection.
+	subscribe := true
 
-	for {
+	for ctx.Err() == nil {
 		select {
-		case ch = <-stop:
-			return
+		case <-connected:
+			if err := InitNetwork(ctx, conn); err != nil {
+				log.Println("network init:", err)
+				// Try again. A reconnect in the meantime may
+				// have already asked us to.
+				select {
+				case connected <- struct{}{}:
+				default:
+				}
+				networkBackoff(ctx)
+				continue
+			}
+			subscribe = true
 		default:
 		}
-		UpdateViewInfo(conn)
-		UpdateMap(conn)
-		UpdateUnits(conn)
+
+		if subscribe {
+			err := SubscribeNetwork(ctx, conn, connected, disconnected)
+			if errors.Is(err, dfhack.ErrNoSubscriptions) {
+				log.Println("network: server can't push updates; polling instead")
+				subscribe = false
+			} else if err != nil {
+				log.Println("network subscribe:", err)
+				networkBackoff(ctx)
+			}
+			continue
+		}
+
+		if err := UpdateNetwork(ctx, conn); err != nil {
+			log.Println("network update:", err)
+			networkBackoff(ctx)
+		}
+	}
+}
+
+// networkBackoff waits a second after a failed request so a persistent
+// error (like the plugin not being loaded) doesn't turn into a busy loop.
+func networkBackoff(ctx context.Context) {
+	t := time.NewTimer(time.Second)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
+
+func InitNetwork(ctx context.Context, conn *dfhack.Conn) error {
+	if err := InitTiletypes(ctx, conn); err != nil {
+		return err
+	}
+	if err := InitMaterials(ctx, conn); err != nil {
+		return err
+	}
+	return InitMap(ctx, conn)
+}
+
+// UpdateNetwork refreshes the view, map, and units. The requests are made
+// concurrently so they are pipelined on the connection rather than each
+// waiting for the previous reply.
+func UpdateNetwork(ctx context.Context, conn *dfhack.Conn) error {
+	updates := []func(context.Context, *dfhack.Conn) error{
+		UpdateViewInfo,
+		UpdateMap,
+		UpdateUnits,
+	}
+
+	errs := make(chan error, len(updates))
+	for _, update := range updates {
+		go func(update func(context.Context, *dfhack.Conn) error) {
+			errs <- update(ctx, conn)
+		}(update)
+	}
+
+	var err error
+	for range updates {
+		if e := <-errs; err == nil {
+			err = e
+		}
 	}
+	return err
 }
 
 func powerOf2(x int) int {
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"sync"
 
 	"github.com/BenLubar/arm_ok/dfhack"
 	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/mapcache"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -71,14 +73,65 @@ var NotLoadedData = []float32{
 	16, 16, 1, 0.2, 0.3, 0.8, 1, 0, 0,
 }
 
-func InitMap(conn *dfhack.Conn) {
-	_, err := conn.ResetMapHashes()
-	if err != nil {
-		panic(err)
+// Load replaces the tiles with the contents of a merged block from
+// MapCache.
+func (tiles *MapBlock) Load(block *RemoteFortressReader.MapBlock) {
+	for i, tt := range block.Tiles {
+		tiles[i%16][i/16].Tiletype = Tiletype(tt)
+	}
+	for i, mat := range block.Materials {
+		tiles[i%16][i/16].Material = Material{
+			Type:  mat.GetMatType(),
+			Index: mat.GetMatIndex(),
+		}
+	}
+	for i, mat := range block.BaseMaterials {
+		tiles[i%16][i/16].Base = Material{
+			Type:  mat.GetMatType(),
+			Index: mat.GetMatIndex(),
+		}
+	}
+	for i, mat := range block.LayerMaterials {
+		tiles[i%16][i/16].Layer = Material{
+			Type:  mat.GetMatType(),
+			Index: mat.GetMatIndex(),
+		}
+	}
+	for i, mat := range block.VeinMaterials {
+		tiles[i%16][i/16].Vein = Material{
+			Type:  mat.GetMatType(),
+			Index: mat.GetMatIndex(),
+		}
+	}
+	for i, w := range block.Water {
+		tiles[i%16][i/16].Water = uint8(w)
+	}
+	for i, m := range block.Magma {
+		tiles[i%16][i/16].Magma = uint8(m)
+	}
+}
+
+func InitMap(ctx context.Context, conn *dfhack.Conn) error {
+	// Throw away anything we loaded from a previous connection; the
+	// world may have changed while we were disconnected.
+	dirtyLock.Lock()
+	for pos := range Map {
+		Dirty[pos] = nil
 	}
+	dirtyLock.Unlock()
+
+	Map = make(map[[3]int32]*MapBlock)
+	MapCache.Reset()
+	mapSame = 0
+
+	_, err := conn.ResetMapHashesContext(ctx)
+	return err
 }
 
 var (
+	// MapCache holds the blocks as DFHack sent them; Map holds the same
+	// blocks converted for rendering.
+	MapCache  = mapcache.New()
 	Map       = make(map[[3]int32]*MapBlock)
 	mapSame   int32
 	Dirty     = make(map[[3]int32][]float32)
@@ -93,10 +146,10 @@ const (
 	rangeZchunk = 5
 )
 
-func UpdateMap(conn *dfhack.Conn) {
+func UpdateMap(ctx context.Context, conn *dfhack.Conn) error {
 	center := FindCenter()
 
-	blocks, _, err := conn.GetBlockList(&RemoteFortressReader.BlockRequest{
+	blocks, _, err := conn.GetBlockListContext(ctx, &RemoteFortressReader.BlockRequest{
 		MinX: proto.Int32(center[0] - rangeX),
 		MaxX: proto.Int32(center[0] + rangeX),
 		MinY: proto.Int32(center[1] - rangeY),
@@ -107,84 +160,63 @@ func UpdateMap(conn *dfhack.Conn) {
 		BlocksNeeded: proto.Int32(1),
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
+	if ApplyBlocks(blocks) == 0 {
+		mapSame += rangeZchunk
+		mapSame %= rangeZdown
+	} else {
+		mapSame = 0
+	}
+
+	return nil
+}
+
+// MapRequest asks for every block in range of center.
+func MapRequest(center [3]int32) *RemoteFortressReader.BlockRequest {
+	return &RemoteFortressReader.BlockRequest{
+		MinX: proto.Int32(center[0] - rangeX),
+		MaxX: proto.Int32(center[0] + rangeX),
+		MinY: proto.Int32(center[1] - rangeY),
+		MaxY: proto.Int32(center[1] + rangeY),
+		MinZ: proto.Int32(center[2] - rangeZdown),
+		MaxZ: proto.Int32(center[2] + rangeZup + 1),
+	}
+}
+
+// ApplyBlocks adds the blocks to the map and returns the number that
+// changed.
+func ApplyBlocks(blocks *RemoteFortressReader.BlockList) int {
 	type dirty struct {
 		pos  [3]int32
 		data []float32
 	}
 	var next []dirty
 
-	for _, block := range blocks.MapBlocks {
-		pos := [3]int32{block.GetMapX() / 16, block.GetMapY() / 16, block.GetMapZ()}
+	changed := MapCache.ApplyList(blocks)
+	for _, p := range changed {
+		block, _ := MapCache.Get(p)
+
+		pos := [3]int32(p)
 		tiles, ok := Map[pos]
 		if !ok {
 			tiles = new(MapBlock)
 			Map[pos] = tiles
 		}
-		any := false
-		for i, tt := range block.Tiles {
-			tiles[i%16][i/16].Tiletype = Tiletype(tt)
-			any = true
-		}
-		for i, mat := range block.Materials {
-			tiles[i%16][i/16].Material = Material{
-				Type:  mat.GetMatType(),
-				Index: mat.GetMatIndex(),
-			}
-			any = true
-		}
-		for i, mat := range block.BaseMaterials {
-			tiles[i%16][i/16].Base = Material{
-				Type:  mat.GetMatType(),
-				Index: mat.GetMatIndex(),
-			}
-			any = true
-		}
-		for i, mat := range block.LayerMaterials {
-			tiles[i%16][i/16].Layer = Material{
-				Type:  mat.GetMatType(),
-				Index: mat.GetMatIndex(),
-			}
-			any = true
-		}
-		for i, mat := range block.VeinMaterials {
-			tiles[i%16][i/16].Vein = Material{
-				Type:  mat.GetMatType(),
-				Index: mat.GetMatIndex(),
-			}
-			any = true
-		}
-		for i, w := range block.Water {
-			tiles[i%16][i/16].Water = uint8(w)
-			any = true
-		}
-		for i, m := range block.Magma {
-			tiles[i%16][i/16].Magma = uint8(m)
-			any = true
-		}
+		tiles.Load(block.Block)
 
-		if any {
-			next = append(next, dirty{pos, tiles.Generate(pos)})
-			checkAdjacent := func(dx, dy int32) {
-				o := [3]int32{pos[0] + dx, pos[1] + dy, pos[2]}
-				if b := Map[o]; b != nil {
-					next = append(next, dirty{o, b.Generate(o)})
-				}
+		next = append(next, dirty{pos, tiles.Generate(pos)})
+		checkAdjacent := func(dx, dy int32) {
+			o := [3]int32{pos[0] + dx, pos[1] + dy, pos[2]}
+			if b := Map[o]; b != nil {
+				next = append(next, dirty{o, b.Generate(o)})
 			}
-			checkAdjacent(-1, 0)
-			checkAdjacent(0, 1)
-			checkAdjacent(0, -1)
-			checkAdjacent(0, 1)
 		}
-	}
-
-	if len(next) == 0 {
-		mapSame += rangeZchunk
-		mapSame %= rangeZdown
-	} else {
-		mapSame = 0
+		checkAdjacent(-1, 0)
+		checkAdjacent(0, 1)
+		checkAdjacent(0, -1)
+		checkAdjacent(0, 1)
 	}
 
 	dirtyLock.Lock()
@@ -192,4 +224,6 @@ func UpdateMap(conn *dfhack.Conn) {
 	for _, d := range next {
 		Dirty[d.pos] = d.data
 	}
+
+	return len(changed)
 }
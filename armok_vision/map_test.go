@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestMapBlockLoad(t *testing.T) {
+	block := &RemoteFortressReader.MapBlock{
+		Tiles:     make([]int32, 256),
+		Materials: make([]*RemoteFortressReader.MatPair, 256),
+		Water:     make([]int32, 256),
+	}
+	for i := range block.Materials {
+		block.Materials[i] = &RemoteFortressReader.MatPair{MatType: proto.Int32(0), MatIndex: proto.Int32(0)}
+	}
+	block.Tiles[1] = 7
+	block.Materials[16] = &RemoteFortressReader.MatPair{MatType: proto.Int32(3), MatIndex: proto.Int32(4)}
+	block.Water[255] = 6
+
+	var tiles MapBlock
+	tiles.Load(block)
+
+	// Tiles are sent row by row.
+	if tt := tiles[1][0].Tiletype; tt != 7 {
+		t.Errorf("tiletype at (1, 0): got %d, want 7", tt)
+	}
+	if mat := tiles[0][1].Material; mat != (Material{Type: 3, Index: 4}) {
+		t.Errorf("material at (0, 1): got %v", mat)
+	}
+	if w := tiles[15][15].Water; w != 6 {
+		t.Errorf("water at (15, 15): got %d, want 6", w)
+	}
+
+	// Parts of the block that weren't sent are left alone.
+	if mat := tiles[15][15].Base; mat != (Material{}) {
+		t.Errorf("base material at (15, 15): got %v", mat)
+	}
+}
+
+func TestUpdateMap(t *testing.T) {
+	var mtx sync.Mutex
+	tile := int32(1)
+
+	s := dfhacktest.NewServer()
+	defer s.Close()
+	s.Reply("RemoteFortressReader", "GetTiletypeList", &RemoteFortressReader.TiletypeList{
+		TiletypeList: []*RemoteFortressReader.Tiletype{
+			{Id: proto.Int32(1), Shape: RemoteFortressReader.TiletypeShape_WALL.Enum()},
+			{Id: proto.Int32(2), Shape: RemoteFortressReader.TiletypeShape_FLOOR.Enum()},
+		},
+	})
+	s.Reply("RemoteFortressReader", "GetMaterialList", &RemoteFortressReader.MaterialList{})
+	s.Reply("RemoteFortressReader", "ResetMapHashes", nil)
+	s.Handle("RemoteFortressReader", "GetBlockList", func(proto.Message) dfhacktest.Reply {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		tiles := make([]int32, 256)
+		for i := range tiles {
+			tiles[i] = tile
+		}
+		return dfhacktest.Reply{Result: &RemoteFortressReader.BlockList{
+			MapBlocks: []*RemoteFortressReader.MapBlock{{
+				MapX:  proto.Int32(16),
+				MapY:  proto.Int32(16),
+				MapZ:  proto.Int32(5),
+				Tiles: tiles,
+			}},
+		}}
+	})
+
+	conn, err := s.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	if err := InitNetwork(ctx, conn); err != nil {
+		t.Fatal(err)
+	}
+	SetViewInfo(&RemoteFortressReader.ViewInfo{
+		ViewPosX: proto.Int32(16),
+		ViewPosY: proto.Int32(16),
+		ViewPosZ: proto.Int32(5),
+	})
+	defer SetViewInfo(nil)
+
+	pos := [3]int32{1, 1, 5}
+	update := func() (dirty bool) {
+		dirtyLock.Lock()
+		delete(Dirty, pos)
+		dirtyLock.Unlock()
+
+		if err := UpdateMap(ctx, conn); err != nil {
+			t.Fatal(err)
+		}
+
+		dirtyLock.Lock()
+		defer dirtyLock.Unlock()
+		_, dirty = Dirty[pos]
+		return
+	}
+
+	if !update() {
+		t.Error("new block not marked dirty")
+	}
+	if tiles := Map[pos]; tiles == nil || tiles[3][4].Tiletype != 1 {
+		t.Errorf("new block not loaded: %v", tiles)
+	}
+
+	// An unchanged block isn't generated again, and the next update looks
+	// further down.
+	if update() {
+		t.Error("unchanged block marked dirty")
+	}
+	if mapSame != rangeZchunk {
+		t.Errorf("after an update with no changes, mapSame = %d, want %d", mapSame, rangeZchunk)
+	}
+
+	mtx.Lock()
+	tile = 2
+	mtx.Unlock()
+	if !update() {
+		t.Error("changed block not marked dirty")
+	}
+	if tiles := Map[pos]; tiles[3][4].Tiletype != 2 {
+		t.Errorf("changed block not loaded: tiletype %d", tiles[3][4].Tiletype)
+	}
+	if mapSame != 0 {
+		t.Errorf("after an update with changes, mapSame = %d, want 0", mapSame)
+	}
+
+	// Reconnecting throws away the map and clears what was drawn.
+	if err := InitMap(ctx, conn); err != nil {
+		t.Fatal(err)
+	}
+	if len(Map) != 0 {
+		t.Errorf("map not cleared: %d blocks", len(Map))
+	}
+	dirtyLock.Lock()
+	if data, ok := Dirty[pos]; !ok || data != nil {
+		t.Errorf("block not cleared from the screen: %v, %v", data != nil, ok)
+	}
+	dirtyLock.Unlock()
+}
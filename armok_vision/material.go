@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"github.com/BenLubar/arm_ok/dfhack"
 	"github.com/go-gl/mathgl/mgl32"
 )
@@ -21,10 +22,10 @@ var Materials map[Material]MaterialDef
 
 func (mat Material) Def() MaterialDef { return Materials[mat] }
 
-func InitMaterials(conn *dfhack.Conn) {
-	list, _, err := conn.GetMaterialList()
+func InitMaterials(ctx context.Context, conn *dfhack.Conn) error {
+	list, _, err := conn.GetMaterialListContext(ctx)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	Materials = make(map[Material]MaterialDef)
@@ -42,4 +43,6 @@ func InitMaterials(conn *dfhack.Conn) {
 			},
 		}
 	}
+
+	return nil
 }
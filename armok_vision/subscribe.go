@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	getViewInfo, _  = dfhack.LookupMethod("RemoteFortressReader", "GetViewInfo")
+	getUnitList, _  = dfhack.LookupMethod("RemoteFortressReader", "GetUnitList")
+	getBlockList, _ = dfhack.LookupMethod("RemoteFortressReader", "GetBlockList")
+)
+
+// SubscribeNetwork has the server push the view, map, and units when they
+// change, and applies the updates until ctx is done or the connection is
+// re-established, leaving the connected signal for the caller. It returns
+// dfhack.ErrNoSubscriptions if the server can't push updates (only
+// armok_web can), in which case UpdateNetwork must be polled instead.
+//
+// Subscriptions end with the connection, and a connection is only
+// re-established when a call is made, so a signal on disconnected makes it
+// subscribe again. The calls wait for the connection to come back.
+func SubscribeNetwork(ctx context.Context, conn *dfhack.Conn, connected, disconnected chan struct{}) error {
+	for {
+		// A disconnect before subscribing is taken care of by the
+		// calls below.
+		select {
+		case <-disconnected:
+		default:
+		}
+
+		err := subscribeNetwork(ctx, conn, connected, disconnected)
+		if err != nil && err != errDisconnected && ctx.Err() == nil {
+			// A call fails if the connection is lost while
+			// subscribing.
+			select {
+			case <-disconnected:
+				err = errDisconnected
+			default:
+			}
+		}
+		if err != errDisconnected {
+			return err
+		}
+	}
+}
+
+var errDisconnected = errors.New("armok_vision: disconnected")
+
+func subscribeNetwork(ctx context.Context, conn *dfhack.Conn, connected, disconnected chan struct{}) error {
+	var q pushQueue
+	q.ready = make(chan struct{}, 1)
+
+	var info RemoteFortressReader.ViewInfo
+	if _, err := conn.Subscribe(ctx, getViewInfo, &dfproto.EmptyMessage{}, &info, q.push); err != nil {
+		return err
+	}
+	SetViewInfo(&info)
+
+	var units RemoteFortressReader.UnitList
+	if _, err := conn.Subscribe(ctx, getUnitList, &dfproto.EmptyMessage{}, &units, q.push); err != nil {
+		return err
+	}
+	SetUnits(&units)
+
+	// The map is subscribed to again whenever the view moves. Each reply
+	// only has the blocks that changed since the last one.
+	var center [3]int32
+	subscribeMap := func() error {
+		center = FindCenter()
+
+		var blocks RemoteFortressReader.BlockList
+		if _, err := conn.Subscribe(ctx, getBlockList, MapRequest(center), &blocks, q.push); err != nil {
+			return err
+		}
+		ApplyBlocks(&blocks)
+		return nil
+	}
+	if err := subscribeMap(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-connected:
+			// Someone else may have signalled again already.
+			select {
+			case connected <- struct{}{}:
+			default:
+			}
+			return nil
+		case <-disconnected:
+			return errDisconnected
+		case <-q.ready:
+		}
+
+		for _, msg := range q.take() {
+			switch m := msg.(type) {
+			case *RemoteFortressReader.ViewInfo:
+				SetViewInfo(m)
+			case *RemoteFortressReader.UnitList:
+				SetUnits(m)
+			case *RemoteFortressReader.BlockList:
+				ApplyBlocks(m)
+			}
+		}
+
+		if FindCenter() != center {
+			if err := subscribeMap(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pushQueue holds pushed replies until the network goroutine applies them.
+// Pushes arrive on the connection's reader, which must not wait for us.
+type pushQueue struct {
+	mtx   sync.Mutex
+	msgs  []proto.Message
+	ready chan struct{}
+}
+
+func (q *pushQueue) push(msg proto.Message) {
+	q.mtx.Lock()
+	q.msgs = append(q.msgs, msg)
+	q.mtx.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (q *pushQueue) take() []proto.Message {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	msgs := q.msgs
+	q.msgs = nil
+	return msgs
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestSubscribeReconnect(t *testing.T) {
+	subscribed := make(chan struct{}, 1)
+
+	s := dfhacktest.NewServer()
+	defer s.Close()
+	s.Handle("RemoteFortressReader", "GetViewInfo", func(proto.Message) dfhacktest.Reply {
+		select {
+		case subscribed <- struct{}{}:
+		default:
+		}
+		return dfhacktest.Reply{Result: &RemoteFortressReader.ViewInfo{}, Subscribed: true}
+	})
+	s.Handle("RemoteFortressReader", "GetUnitList", func(proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{Result: &RemoteFortressReader.UnitList{}, Subscribed: true}
+	})
+	s.Handle("RemoteFortressReader", "GetBlockList", func(proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{Result: &RemoteFortressReader.BlockList{}, Subscribed: true}
+	})
+	defer SetViewInfo(nil)
+
+	connected := make(chan struct{}, 1)
+	disconnected := make(chan struct{}, 1)
+	conn, err := s.Dial(&dfhack.Dialer{
+		Reconnect:  true,
+		MinBackoff: time.Millisecond,
+		StateChanged: func(state dfhack.ConnState, err error) {
+			signal := connected
+			if state != dfhack.StateConnected {
+				signal = disconnected
+			}
+			select {
+			case signal <- struct{}{}:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	<-connected
+
+	// Subscribe like Network does, starting over after each reconnect.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		for ctx.Err() == nil {
+			select {
+			case <-connected:
+			default:
+			}
+			if err := SubscribeNetwork(ctx, conn, connected, disconnected); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	wait := func(what string) {
+		select {
+		case <-subscribed:
+		case err := <-done:
+			t.Fatalf("%s: SubscribeNetwork returned %v", what, err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: not subscribed", what)
+		}
+	}
+	wait("first connection")
+
+	// Nothing is called while waiting for pushes, so only subscribing
+	// again brings the connection back. The connection may be lost
+	// before all three subscriptions are made, too.
+	s.Disconnect()
+	wait("after disconnecting")
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("SubscribeNetwork: %v", err)
+	}
+}
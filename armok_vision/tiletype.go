@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"github.com/BenLubar/arm_ok/dfhack"
 	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
 )
@@ -22,10 +23,10 @@ var Tiletypes map[Tiletype]TiletypeDef
 
 func (tt Tiletype) Def() TiletypeDef { return Tiletypes[tt] }
 
-func InitTiletypes(conn *dfhack.Conn) {
-	list, _, err := conn.GetTiletypeList()
+func InitTiletypes(ctx context.Context, conn *dfhack.Conn) error {
+	list, _, err := conn.GetTiletypeListContext(ctx)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	Tiletypes = make(map[Tiletype]TiletypeDef)
@@ -40,4 +41,6 @@ func InitTiletypes(conn *dfhack.Conn) {
 			Direction: tt.GetDirection(),
 		}
 	}
+
+	return nil
 }
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"math/rand"
 	"sync"
 
 	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
 )
 
 var UnitData = []float32{
@@ -64,12 +66,18 @@ type Unit struct {
 var Units map[int32]Unit
 var unitLock sync.Mutex
 
-func UpdateUnits(conn *dfhack.Conn) {
-	list, _, err := conn.GetUnitList()
+func UpdateUnits(ctx context.Context, conn *dfhack.Conn) error {
+	list, _, err := conn.GetUnitListContext(ctx)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
+	SetUnits(list)
+
+	return nil
+}
+
+func SetUnits(list *RemoteFortressReader.UnitList) {
 	units := make(map[int32]Unit)
 
 	for _, u := range list.CreatureList {
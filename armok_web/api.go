@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/BenLubar/arm_ok/dfhack/mapcache"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// APIRoutes are shorthand paths for common calls. Any allowed method can
+// also be called at /api/rpc/Method or /api/rpc/Plugin/Method.
+var APIRoutes = map[string][2]string{
+	"/api/version":    {"", "GetDFVersion"},
+	"/api/world":      {"", "GetWorldInfo"},
+	"/api/enums":      {"", "ListEnums"},
+	"/api/units":      {pluginRemoteFortressReader, "GetUnitList"},
+	"/api/materials":  {pluginRemoteFortressReader, "GetMaterialList"},
+	"/api/tiletypes":  {pluginRemoteFortressReader, "GetTiletypeList"},
+	"/api/view":       {pluginRemoteFortressReader, "GetViewInfo"},
+	"/api/map":        {pluginRemoteFortressReader, "GetMapInfo"},
+	"/api/map/blocks": {pluginRemoteFortressReader, "GetBlockList"},
+}
+
+func init() {
+	mux.Handle("/api/", requireAuth(http.HandlerFunc(serveAPI)))
+}
+
+// serveAPI calls a method with a request read from the query string (for
+// GET) or a JSON body (for POST), and replies with
+//
+//	{"result": ..., "text": "..."}
+//
+// or, if the call fails,
+//
+//	{"error": "...", "code": -1, "text": "..."}
+//
+// Messages use the protobuf JSON mapping; query parameters are the names of
+// fields in the request, such as min_x. Methods that need more than the
+// viewer role can change the game, so they must be called with a POST of
+// application/json, which a link or a form on another site can't make.
+func serveAPI(w http.ResponseWriter, r *http.Request) {
+	f := requestFortress(r)
+	if f == nil {
+		writeAPIError(w, http.StatusNotFound, "no such fortress", 0, "")
+		return
+	}
+
+	method, ok := APIRoutes[r.URL.Path]
+	if !ok && strings.HasPrefix(r.URL.Path, "/api/rpc/") {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/rpc/"), "/")
+		switch len(parts) {
+		case 1:
+			method, ok = [2]string{"", parts[0]}, true
+		case 2:
+			method, ok = [2]string{parts[0], parts[1]}, true
+		}
+	}
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "no such API", 0, "")
+		return
+	}
+
+	var id int16
+	if method[0] == "" {
+		id, ok = CoreMessages[method[1]]
+	} else {
+		id, ok = PluginMessages[method[0]][method[1]]
+	}
+	if !ok || AllowedMessages[id].Rule == nil || id == 0 {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("RPC method not found: %s::%s", method[0], method[1]), 0, "")
+		return
+	}
+	msg := AllowedMessages[id]
+
+	role := requestRole(r)
+	if msg.Rule.role > role {
+		writeAPIError(w, http.StatusForbidden, fmt.Sprintf("RPC method requires the %v role: %s::%s", msg.Rule.role, msg.Plugin, msg.Name), 0, "")
+		return
+	}
+
+	if msg.Rule.role > RoleViewer && r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeAPIError(w, http.StatusMethodNotAllowed, fmt.Sprintf("RPC method must be called with POST: %s::%s", msg.Plugin, msg.Name), 0, "")
+		return
+	}
+
+	req := msg.NewIn()
+	if err := readAPIRequest(r, req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error(), 0, "")
+		return
+	}
+	b, err := proto.Marshal(req)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error(), 0, "")
+		return
+	}
+
+	client := apiClientFor(r, role, f)
+	client.mtx.Lock()
+	defer client.mtx.Unlock()
+
+	// Each call is a session of its own, so it counts towards
+	// -max-viewers and holds up shutdown until it is done.
+	session, err := Sessions.Open(r.Context(), client.addr, role, f)
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err.Error(), 0, "")
+		return
+	}
+	defer Sessions.Close(session)
+
+	// Every call is answered as if by a new client, so GetBlockList
+	// sends every block. The rate limits carry over between calls.
+	session.Hashes = make(mapcache.Hashes)
+	session.limiters = client.limiters
+	defer func() { client.limiters = session.limiters }()
+
+	f.Connect()
+
+	// The call goes through the same handler as a websocket client's,
+	// writing its reply to a buffer to be translated.
+	var out bytes.Buffer
+	ctx := &proxy_ctx{
+		b:       b,
+		w:       &out,
+		session: session,
+	}
+
+	if ok, err := ctx.Limit(msg.Rule, msg.Plugin+"::"+msg.Name); !ok {
+		if err != nil {
+			writeAPIError(w, http.StatusServiceUnavailable, err.Error(), 0, "")
+			return
+		}
+		text, _, code, _ := parseReply(out.Bytes())
+		writeAPIError(w, http.StatusTooManyRequests, "rate limited", code, text)
+		return
+	}
+
+	if err := msg.Handle(ctx); err != nil {
+		writeAPIError(w, http.StatusBadGateway, err.Error(), 0, "")
+		return
+	}
+
+	text, result, code, err := parseReply(out.Bytes())
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error(), 0, "")
+		return
+	}
+	if code != 0 {
+		writeAPIError(w, apiStatus(code), "RPC call failed", code, text)
+		return
+	}
+
+	resp := msg.NewOut()
+	if err := proto.Unmarshal(result, resp); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error(), 0, "")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, resp); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error(), 0, "")
+		return
+	}
+
+	writeAPI(w, http.StatusOK, &struct {
+		Result json.RawMessage `json:"result"`
+		Text   string          `json:"text,omitempty"`
+	}{buf.Bytes(), text})
+}
+
+var errNotJSON = errors.New("request body must be application/json")
+
+func readAPIRequest(r *http.Request, req proto.Message) error {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		query := r.URL.Query()
+		if len(query) == 0 {
+			return nil
+		}
+
+		// Build a JSON object out of the parameters. The JSON mapping
+		// accepts numbers as strings, but not booleans.
+		desc := proto.MessageReflect(req).Descriptor().Fields()
+		fields := make(map[string]interface{}, len(query))
+		for name, values := range query {
+			if name == "token" || name == "invite" {
+				continue
+			}
+
+			fd := desc.ByName(protoreflect.Name(name))
+			if fd == nil {
+				fd = desc.ByJSONName(name)
+			}
+			if len(values) == 1 {
+				fields[name] = queryValue(fd, values[0])
+			} else {
+				list := make([]interface{}, len(values))
+				for i, v := range values {
+					list[i] = queryValue(fd, v)
+				}
+				fields[name] = list
+			}
+		}
+		b, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		return jsonpb.Unmarshal(bytes.NewReader(b), req)
+
+	case http.MethodPost:
+		if t, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || t != "application/json" {
+			return errNotJSON
+		}
+
+		b, err := ioutil.ReadAll(io.LimitReader(r.Body, int64(maxMessageSize)))
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(b)) == 0 {
+			return nil
+		}
+		return jsonpb.Unmarshal(bytes.NewReader(b), req)
+	}
+
+	return fmt.Errorf("method %s not allowed", r.Method)
+}
+
+// queryValue returns s as the JSON value of a query parameter for field fd,
+// which is nil if the message has no such field.
+func queryValue(fd protoreflect.FieldDescriptor, s string) interface{} {
+	if fd != nil && fd.Kind() == protoreflect.BoolKind {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
+
+// parseReply decodes the messages a handler wrote. If the call failed,
+// code is its result code.
+func parseReply(b []byte) (text string, result []byte, code int32, err error) {
+	var buf strings.Builder
+	for len(b) != 0 {
+		var header rpcMessageHeader
+		if len(b) < binary.Size(header) {
+			return "", nil, 0, io.ErrUnexpectedEOF
+		}
+		if err = binary.Read(bytes.NewReader(b), binary.LittleEndian, &header); err != nil {
+			return
+		}
+		b = b[binary.Size(header):]
+
+		if header.ID == rpcReplyFail {
+			return buf.String(), nil, header.Size, nil
+		}
+		if header.Size < 0 || int(header.Size) > len(b) {
+			return "", nil, 0, io.ErrUnexpectedEOF
+		}
+		body := b[:header.Size]
+		b = b[header.Size:]
+
+		switch header.ID {
+		case rpcReplyText:
+			var t dfproto.CoreTextNotification
+			if err = proto.Unmarshal(body, &t); err != nil {
+				return
+			}
+			for _, f := range t.GetFragments() {
+				buf.WriteString(f.GetText())
+			}
+		case rpcReplyResult:
+			return buf.String(), body, 0, nil
+		}
+	}
+
+	return "", nil, 0, io.ErrUnexpectedEOF
+}
+
+func apiStatus(code int32) int {
+	switch code {
+	case cr_not_found:
+		return http.StatusNotFound
+	case cr_wrong_usage:
+		return http.StatusBadRequest
+	case cr_not_implemented, cr_needs_console:
+		return http.StatusNotImplemented
+	case cr_link_failure:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusBadGateway
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string, code int32, text string) {
+	writeAPI(w, status, &struct {
+		Error string `json:"error"`
+		Code  int32  `json:"code,omitempty"`
+		Text  string `json:"text,omitempty"`
+	}{message, code, text})
+}
+
+func writeAPI(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// An apiClient holds the rate limits for API calls from one address to one
+// fortress. Its calls are made one at a time, like a websocket client's.
+type apiClient struct {
+	mtx      sync.Mutex
+	addr     string
+	limiters map[*PolicyRule]*tokenBucket
+	lastUsed time.Time
+}
+
+var (
+	apiClientsLock sync.Mutex
+	apiClients     = make(map[string]*apiClient)
+)
+
+func apiClientFor(r *http.Request, role Role, f *Fortress) *apiClient {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	key := host + " " + role.String() + " " + f.Name
+
+	apiClientsLock.Lock()
+	defer apiClientsLock.Unlock()
+
+	now := time.Now()
+	for k, c := range apiClients {
+		// Forget the rate limits of clients that have gone away.
+		if now.Sub(c.lastUsed) > 10*time.Minute {
+			delete(apiClients, k)
+		}
+	}
+
+	c, ok := apiClients[key]
+	if !ok {
+		c = &apiClient{addr: host}
+		apiClients[key] = c
+	}
+	c.lastUsed = now
+
+	return c
+}
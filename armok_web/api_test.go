@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestReadAPIRequest(t *testing.T) {
+	tests := []struct {
+		query string
+		req   proto.Message
+		want  proto.Message
+	}{
+		{"", &RemoteFortressReader.SingleBool{}, &RemoteFortressReader.SingleBool{}},
+		{"Value=true", &RemoteFortressReader.SingleBool{}, &RemoteFortressReader.SingleBool{Value: proto.Bool(true)}},
+		{"Value=false&token=view", &RemoteFortressReader.SingleBool{}, &RemoteFortressReader.SingleBool{Value: proto.Bool(false)}},
+		{"Value=1", &RemoteFortressReader.SingleBool{}, &RemoteFortressReader.SingleBool{Value: proto.Bool(true)}},
+		{"Value=yes", &RemoteFortressReader.SingleBool{}, nil},
+		{"min_x=3&maxX=5", &RemoteFortressReader.BlockRequest{}, &RemoteFortressReader.BlockRequest{MinX: proto.Int32(3), MaxX: proto.Int32(5)}},
+		{"min_x=three", &RemoteFortressReader.BlockRequest{}, nil},
+		{"no_such_field=1", &RemoteFortressReader.BlockRequest{}, nil},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/api/rpc?"+tt.query, nil)
+		err := readAPIRequest(r, tt.req)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("%q: got %v, want an error", tt.query, tt.req)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.query, err)
+		} else if !proto.Equal(tt.req, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, tt.req, tt.want)
+		}
+	}
+}
+
+func TestAPISessions(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	get := func(path string) (int, map[string]interface{}) {
+		resp, err := http.Get(p.HTTP.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, body
+	}
+
+	if status, body := get("/api/version?token=view"); status != http.StatusOK {
+		t.Errorf("GetDFVersion: %d %v", status, body)
+	}
+	if n := Sessions.Len(); n != 0 {
+		t.Errorf("%d sessions left open after a call", n)
+	}
+
+	// API calls count towards -max-viewers.
+	Sessions.MaxSessions = 1
+	defer func() { Sessions.MaxSessions = 0 }()
+	s, err := Sessions.Open(context.Background(), "test", RoleViewer, Fortresses[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, body := get("/api/version?token=view"); status != http.StatusServiceUnavailable || body["error"] != ErrTooManySessions.Error() {
+		t.Errorf("GetDFVersion with too many viewers: %d %v", status, body)
+	}
+	Sessions.Close(s)
+
+	// A call in progress holds up Drain, and calls after it are turned
+	// away.
+	const delay = 100 * time.Millisecond
+	p.DFHack.Handle("RemoteFortressReader", "GetViewInfo", func(proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{Result: &RemoteFortressReader.ViewInfo{}, Delay: delay}
+	})
+	start := time.Now()
+	done := make(chan int)
+	go func() {
+		status, _ := get("/api/view?token=view")
+		done <- status
+	}()
+	for Sessions.Len() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := Sessions.Drain(ctx); err != nil {
+		t.Error("draining:", err)
+	}
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("Drain returned after %v, before the API call finished", elapsed)
+	}
+	if status := <-done; status != http.StatusOK {
+		t.Errorf("call during Drain: %d", status)
+	}
+
+	if status, body := get("/api/version?token=view"); status != http.StatusServiceUnavailable || body["error"] != ErrShuttingDown.Error() {
+		t.Errorf("GetDFVersion after Drain: %d %v", status, body)
+	}
+}
+
+func TestAPIMethods(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	paused := make(chan bool, 1)
+	p.DFHack.Handle("RemoteFortressReader", "SetPauseState", func(req proto.Message) dfhacktest.Reply {
+		paused <- req.(*RemoteFortressReader.SingleBool).GetValue()
+		return dfhacktest.Reply{}
+	})
+
+	const setPause = "/api/rpc/RemoteFortressReader/SetPauseState?token=control"
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		status      int
+		paused      bool
+	}{
+		{"GET SetPauseState", http.MethodGet, setPause + "&Value=true", "", "", http.StatusMethodNotAllowed, false},
+		{"GET core SetPauseState", http.MethodGet, "/api/rpc/SetPauseState?token=control", "", "", http.StatusNotFound, false},
+		{"HEAD SetPauseState", http.MethodHead, setPause + "&Value=true", "", "", http.StatusMethodNotAllowed, false},
+		{"GET RunCommand", http.MethodGet, "/api/rpc/RunCommand?token=control&command=die", "", "", http.StatusMethodNotAllowed, false},
+		{"form POST", http.MethodPost, setPause, "application/x-www-form-urlencoded", "Value=true", http.StatusBadRequest, false},
+		{"text POST", http.MethodPost, setPause, "text/plain", `{"Value": true}`, http.StatusBadRequest, false},
+		{"JSON POST", http.MethodPost, setPause, "application/json; charset=utf-8", `{"Value": true}`, http.StatusOK, true},
+		{"viewer GET", http.MethodGet, "/api/view?token=view", "", "", http.StatusOK, false},
+		{"viewer HEAD", http.MethodHead, "/api/view?token=view", "", "", http.StatusOK, false},
+		{"viewer JSON POST", http.MethodPost, "/api/view?token=view", "application/json", "{}", http.StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, p.HTTP.URL+tt.path, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: got %s, want %d", tt.name, resp.Status, tt.status)
+		}
+
+		select {
+		case v := <-paused:
+			if !tt.paused {
+				t.Errorf("%s: SetPauseState was called", tt.name)
+			} else if !v {
+				t.Errorf("%s: unpaused", tt.name)
+			}
+		default:
+			if tt.paused {
+				t.Errorf("%s: SetPauseState wasn't called", tt.name)
+			}
+		}
+	}
+}
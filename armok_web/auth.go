@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Role decides which methods a client may call.
+type Role int
+
+const (
+	// RoleNone may not connect.
+	RoleNone Role = iota
+	// RoleViewer may read the state of the game.
+	RoleViewer
+	// RoleController may also change it.
+	RoleController
+)
+
+var roleNames = [...]string{
+	RoleNone:       "none",
+	RoleViewer:     "viewer",
+	RoleController: "controller",
+}
+
+func (r Role) String() string {
+	if r >= 0 && int(r) < len(roleNames) {
+		return roleNames[r]
+	}
+	return "Role(" + strconv.Itoa(int(r)) + ")"
+}
+
+// ParseRole returns the role named s.
+func ParseRole(s string) (Role, error) {
+	for r, name := range roleNames {
+		if name == s {
+			return Role(r), nil
+		}
+	}
+	return RoleNone, fmt.Errorf("armok_web: unknown role %q", s)
+}
+
+var (
+	ErrNoCredentials  = errors.New("armok_web: no credentials")
+	ErrBadCredentials = errors.New("armok_web: invalid credentials")
+)
+
+// An Authenticator decides the role of the client making a request.
+type Authenticator interface {
+	// Authenticate returns ErrNoCredentials if r has no credentials of
+	// the kind it understands, so that the next Authenticator can be
+	// tried.
+	Authenticate(r *http.Request) (Role, error)
+}
+
+// Authenticators are tried in order for each client. If there are none,
+// every client is a viewer.
+var Authenticators []Authenticator
+
+func authenticate(r *http.Request) (Role, error) {
+	if len(Authenticators) == 0 {
+		return RoleViewer, nil
+	}
+
+	for _, a := range Authenticators {
+		role, err := a.Authenticate(r)
+		if err == ErrNoCredentials {
+			continue
+		}
+		return role, err
+	}
+
+	return RoleNone, ErrNoCredentials
+}
+
+type roleKey struct{}
+
+// requireAuth wraps h so that it is only called for authenticated clients.
+// The client's role can be retrieved with requestRole.
+func requireAuth(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, err := authenticate(r)
+		if err != nil {
+			for _, a := range Authenticators {
+				if b, ok := a.(*BasicAuth); ok {
+					w.Header().Set("WWW-Authenticate", `Basic realm="`+b.Realm+`"`)
+					break
+				}
+			}
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), roleKey{}, role)))
+	})
+}
+
+func requestRole(r *http.Request) Role {
+	role, _ := r.Context().Value(roleKey{}).(Role)
+	return role
+}
+
+// AnonymousAuth gives every client the same role.
+type AnonymousAuth Role
+
+func (a AnonymousAuth) Authenticate(r *http.Request) (Role, error) {
+	return Role(a), nil
+}
+
+// TokenAuth accepts a shared secret, either in the token query parameter
+// or as a bearer token.
+type TokenAuth struct {
+	Token string
+	Role  Role
+}
+
+func (a *TokenAuth) Authenticate(r *http.Request) (Role, error) {
+	token := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); token == "" && strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if token == "" {
+		return RoleNone, ErrNoCredentials
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) != 1 {
+		// Another TokenAuth may have a different role.
+		return RoleNone, ErrNoCredentials
+	}
+
+	return a.Role, nil
+}
+
+// BasicAuth accepts HTTP basic authentication.
+type BasicAuth struct {
+	Realm string
+	Users map[string]BasicUser
+}
+
+type BasicUser struct {
+	// PasswordHash is the SHA-256 hash of the user's password.
+	PasswordHash [sha256.Size]byte
+	Role         Role
+}
+
+// LoadBasicAuth reads a file of users, one per line, in the form
+//
+//	name:hex-sha256-of-password:role
+//
+// Blank lines and lines starting with # are ignored.
+func LoadBasicAuth(name string) (*BasicAuth, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	a := &BasicAuth{
+		Realm: "armok_web",
+		Users: make(map[string]BasicUser),
+	}
+
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		parts := strings.Split(text, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected name:hash:role", name, line)
+		}
+
+		var u BasicUser
+		if hash, err := hex.DecodeString(parts[1]); err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("%s:%d: invalid password hash", name, line)
+		} else {
+			copy(u.PasswordHash[:], hash)
+		}
+		if u.Role, err = ParseRole(parts[2]); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", name, line, err)
+		}
+
+		a.Users[parts[0]] = u
+	}
+
+	return a, s.Err()
+}
+
+func (a *BasicAuth) Authenticate(r *http.Request) (Role, error) {
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return RoleNone, ErrNoCredentials
+	}
+
+	u, ok := a.Users[name]
+	hash := sha256.Sum256([]byte(password))
+	if !ok || subtle.ConstantTimeCompare(hash[:], u.PasswordHash[:]) != 1 {
+		return RoleNone, ErrBadCredentials
+	}
+
+	return u.Role, nil
+}
+
+// InviteAuth accepts invite links signed with Key, in the invite query
+// parameter.
+type InviteAuth struct {
+	Key []byte
+}
+
+// Invite returns an invite for role that stops working at expires.
+func (a *InviteAuth) Invite(role Role, expires time.Time) string {
+	payload := role.String() + "." + strconv.FormatInt(expires.Unix(), 10)
+	return payload + "." + a.sign(payload)
+}
+
+func (a *InviteAuth) sign(payload string) string {
+	mac := hmac.New(sha256.New, a.Key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (a *InviteAuth) Authenticate(r *http.Request) (Role, error) {
+	invite := r.URL.Query().Get("invite")
+	if invite == "" {
+		return RoleNone, ErrNoCredentials
+	}
+
+	i := strings.LastIndexByte(invite, '.')
+	if i == -1 || !hmac.Equal([]byte(invite[i+1:]), []byte(a.sign(invite[:i]))) {
+		return RoleNone, ErrBadCredentials
+	}
+
+	parts := strings.Split(invite[:i], ".")
+	if len(parts) != 2 {
+		return RoleNone, ErrBadCredentials
+	}
+	role, err := ParseRole(parts[0])
+	if err != nil {
+		return RoleNone, ErrBadCredentials
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() >= expires {
+		return RoleNone, ErrBadCredentials
+	}
+
+	return role, nil
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/BenLubar/arm_ok/dfhack/mapcache"
+	"github.com/BenLubar/arm_ok/dfhack/respcache"
+	"github.com/golang/protobuf/proto"
+)
+
+// A Fortress is one instance of DFHack served by the proxy. A named
+// fortress is served under /f/name/; the unnamed one, if any, is served at
+// the root. Otherwise, the root lists the named ones, as /f/ always does.
+type Fortress struct {
+	Name string
+	// Addr is the host:port DFHack listens on. Empty means the local
+	// DFHACK_PORT.
+	Addr string
+
+	// MapCache holds every block any client has asked for.
+	MapCache *mapcache.Cache
+
+	// StaticCache holds the replies that only change when a different
+	// world is loaded.
+	StaticCache *respcache.Cache
+
+	// Poller is nil if polling is disabled, in which case every
+	// GetBlockList call goes to DFHack.
+	Poller *Poller
+
+	startOnce sync.Once
+	closing   chan struct{}
+	// attempted is closed once the first attempt to connect is over, so
+	// that the first clients don't fail while it's still in progress.
+	attempted chan struct{}
+
+	// remote is nil while disconnected, and lastErr says why. remoteLost
+	// is closed when remote fails.
+	mtx        sync.Mutex
+	closed     bool
+	remote     *dfhack.Conn
+	remoteLost chan struct{}
+	version    *dfproto.StringMessage
+	dfVersion  *dfproto.StringMessage
+	lastErr    error
+
+	upstream *scheduler
+	pushes   *pushCache
+
+	// limiters holds the limits from the policy that apply to every
+	// client of the fortress together.
+	limitMtx sync.Mutex
+	limiters map[*PolicyRule]*tokenBucket
+}
+
+// NewFortress returns a fortress that allows at most maxUpstream calls to
+// DFHack to be in progress at once. If maxWaiting is not zero, client
+// calls fail with ErrUpstreamBusy rather than wait behind that many
+// others.
+func NewFortress(name, addr string, maxUpstream, maxWaiting int) *Fortress {
+	return &Fortress{
+		Name:        name,
+		Addr:        addr,
+		MapCache:    mapcache.New(),
+		StaticCache: respcache.New(),
+		closing:     make(chan struct{}),
+		attempted:   make(chan struct{}),
+		upstream:    newScheduler(maxUpstream, maxWaiting),
+		pushes:      &pushCache{entries: make(map[pushKey]*pushEntry)},
+	}
+}
+
+func (f *Fortress) limiter(rule *PolicyRule) *tokenBucket {
+	if rule.Rate == 0 {
+		return nil
+	}
+
+	f.limitMtx.Lock()
+	defer f.limitMtx.Unlock()
+
+	b, ok := f.limiters[rule]
+	if !ok {
+		if f.limiters == nil {
+			f.limiters = make(map[*PolicyRule]*tokenBucket)
+		}
+		b = newTokenBucket(rule.Rate, rule.Burst)
+		f.limiters[rule] = b
+	}
+	return b
+}
+
+// Fortresses are set up from flags in main.
+var Fortresses []*Fortress
+
+// LookupFortress returns the fortress with the given name, or nil.
+func LookupFortress(name string) *Fortress {
+	for _, f := range Fortresses {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// ParseFortress parses a -fortress flag of the form name=host:port.
+func ParseFortress(s string) (name, addr string, err error) {
+	i := strings.IndexByte(s, '=')
+	if i == -1 {
+		return "", "", fmt.Errorf("armok_web: fortress %q is not name=host:port", s)
+	}
+	name, addr = s[:i], s[i+1:]
+
+	if name == "" {
+		return "", "", fmt.Errorf("armok_web: fortress %q has no name", s)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return "", "", fmt.Errorf("armok_web: fortress name %q may only contain letters, digits, '-', '_', and '.'", name)
+		}
+	}
+
+	return name, addr, nil
+}
+
+// Reconnect delays grow from minRetryDelay to maxRetryDelay while DFHack
+// stays unreachable.
+const (
+	minRetryDelay  = time.Second
+	maxRetryDelay  = 30 * time.Second
+	connectTimeout = 10 * time.Second
+)
+
+// Connect starts connecting to DFHack the first time it is called. The
+// fortress keeps reconnecting in the background whenever DFHack can't be
+// reached, until Close.
+func (f *Fortress) Connect() {
+	f.startOnce.Do(func() {
+		go f.supervise()
+	})
+}
+
+func (f *Fortress) logPrefix() string {
+	if f.Name != "" {
+		return "remote " + f.Name
+	}
+	return "remote"
+}
+
+func (f *Fortress) supervise() {
+	delay := minRetryDelay
+	attempted, polling := false, false
+
+	for {
+		lost := make(chan struct{})
+		err := f.connect(lost)
+		if !attempted {
+			close(f.attempted)
+			attempted = true
+		}
+		if err == ErrClosed {
+			return
+		}
+
+		if err != nil {
+			log.Printf("%s connect: %v; retrying in %v", f.logPrefix(), err, delay)
+
+			// A failed attempt leaves nothing for clients to use.
+			f.mtx.Lock()
+			f.remote, f.remoteLost = nil, nil
+			f.lastErr = err
+			f.mtx.Unlock()
+
+			select {
+			case <-time.After(delay):
+			case <-f.closing:
+				return
+			}
+
+			if delay *= 2; delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+			continue
+		}
+
+		delay = minRetryDelay
+		if f.Poller != nil && !polling {
+			go f.Poller.Run()
+			polling = true
+		}
+
+		select {
+		case <-lost:
+		case <-f.closing:
+			return
+		}
+	}
+}
+
+// connect dials DFHack and, once it has answered the calls the proxy needs
+// to serve clients, makes the connection available to them. lost is closed
+// when the connection fails.
+func (f *Fortress) connect(lost chan struct{}) error {
+	prefix := f.logPrefix()
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	// lostErr is set, with f.mtx held, when the connection fails, which
+	// may happen before it is made available.
+	var lostOnce sync.Once
+	var lostErr error
+	conn, err := (&dfhack.Dialer{
+		DialSocket: remoteDialSocket(),
+		Stats:      upstreamStats{},
+		Interceptors: []dfhack.Interceptor{
+			f.StaticCache.Intercept,
+		},
+		StateChanged: func(state dfhack.ConnState, err error) {
+			if err != nil {
+				log.Printf("%s %v: %v", prefix, state, err)
+			} else {
+				log.Println(prefix, state)
+			}
+
+			if state != dfhack.StateDisconnected {
+				return
+			}
+
+			lostOnce.Do(func() {
+				if err == nil {
+					err = dfhack.ErrLinkFailure
+				}
+
+				f.mtx.Lock()
+				defer f.mtx.Unlock()
+
+				lostErr = err
+				f.disconnected(lost, err)
+				close(lost)
+			})
+		},
+	}).DialContext(ctx, f.Addr)
+	if err != nil {
+		return err
+	}
+
+	version, _, err := conn.GetVersionContext(ctx)
+	if err == nil {
+		var dfVersion string
+		dfVersion, _, err = conn.GetDFVersionContext(ctx)
+		if err == nil {
+			// The world may have been unloaded or DFHack restarted
+			// since the last connection.
+			f.MapCache.Reset()
+			_, err = conn.ResetMapHashesContext(ctx)
+		}
+
+		f.mtx.Lock()
+		if err == nil && f.closed {
+			err = ErrClosed
+		}
+		if err == nil && lostErr != nil {
+			// It failed after answering, but before disconnected
+			// could tell it was the one in use.
+			err = lostErr
+		}
+		if err == nil {
+			f.remote = conn
+			f.remoteLost = lost
+			f.version = &dfproto.StringMessage{Value: proto.String(version)}
+			f.dfVersion = &dfproto.StringMessage{Value: proto.String(dfVersion)}
+			f.lastErr = nil
+		}
+		f.mtx.Unlock()
+	}
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	return nil
+}
+
+// disconnected stops offering the connection that closes lost to clients
+// after it fails. The fortress must be locked.
+func (f *Fortress) disconnected(lost chan struct{}, err error) {
+	if f.remoteLost != lost {
+		return
+	}
+	f.remote, f.remoteLost = nil, nil
+	f.lastErr = err
+}
+
+// ErrClosed is returned by calls to DFHack after the fortress is closed.
+var ErrClosed = errors.New("armok_web: fortress closed")
+
+// ErrNotConnected is returned in place of calls to DFHack while the fortress
+// isn't connected. It may be wrapped in a linkError with the reason.
+var ErrNotConnected = errors.New("armok_web: not connected to DFHack")
+
+type linkError struct {
+	err error
+}
+
+func (e *linkError) Error() string {
+	return ErrNotConnected.Error() + ": " + e.err.Error()
+}
+
+func (e *linkError) Is(target error) bool {
+	return target == ErrNotConnected
+}
+
+func (e *linkError) Unwrap() error {
+	return e.err
+}
+
+// conn returns the connection to DFHack, or why there isn't one. It starts
+// connecting if nothing has yet, and waits for the first attempt to finish
+// unless ctx is done first.
+func (f *Fortress) conn(ctx context.Context) (*dfhack.Conn, error) {
+	f.Connect()
+
+	select {
+	case <-f.attempted:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	switch {
+	case f.closed:
+		return nil, ErrClosed
+	case f.remote != nil:
+		return f.remote, nil
+	case f.lastErr != nil:
+		return nil, &linkError{f.lastErr}
+	}
+	return nil, ErrNotConnected
+}
+
+// versions returns what DFHack reported when it was last connected to, as
+// GetVersion and GetDFVersion replies.
+func (f *Fortress) versions(ctx context.Context) (version, dfVersion *dfproto.StringMessage, err error) {
+	if _, err := f.conn(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return f.version, f.dfVersion, nil
+}
+
+// Ready checks that DFHack is connected and has a world loaded. The first
+// check starts connecting, if no client has yet.
+func (f *Fortress) Ready(ctx context.Context) error {
+	return f.Background(ctx, func(ctx context.Context, remote *dfhack.Conn) error {
+		_, _, err := remote.GetWorldInfoContext(ctx)
+		return err
+	})
+}
+
+// Close stops polling and reconnecting and disconnects from DFHack, if
+// connected. The fortress can't be used afterwards.
+func (f *Fortress) Close() {
+	f.mtx.Lock()
+	if f.closed {
+		f.mtx.Unlock()
+		return
+	}
+	f.closed = true
+	remote := f.remote
+	f.remote = nil
+	f.mtx.Unlock()
+
+	close(f.closing)
+	if f.Poller != nil {
+		f.Poller.Stop()
+	}
+	if remote != nil {
+		if err := remote.Close(); err != nil {
+			log.Println(f.logPrefix(), "close:", err)
+		}
+	}
+	if err := f.StaticCache.Flush(); err != nil {
+		log.Println(f.logPrefix(), "saving cache:", err)
+	}
+}
+
+// Background runs fn, which calls DFHack on behalf of the server rather
+// than a client, taking its turn like a session would. It always waits,
+// however many calls are ahead of it.
+func (f *Fortress) Background(ctx context.Context, fn func(ctx context.Context, remote *dfhack.Conn) error) error {
+	remote, err := f.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := f.upstream.acquire(ctx, false); err != nil {
+		return err
+	}
+	defer f.upstream.release()
+
+	return fn(ctx, remote)
+}
+
+type fortressKey struct{}
+
+// requestFortress returns the fortress a request is for, or nil if it was
+// made at the root and there is no unnamed fortress.
+func requestFortress(r *http.Request) *Fortress {
+	if f, ok := r.Context().Value(fortressKey{}).(*Fortress); ok {
+		return f
+	}
+	return LookupFortress("")
+}
+
+func init() {
+	// Everything else is served again for each fortress.
+	mux.HandleFunc("/f/", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(fortressKey{}).(*Fortress); ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		rest := strings.TrimPrefix(r.URL.Path, "/f/")
+		if rest == "" {
+			serveFortressList(w, r)
+			return
+		}
+
+		i := strings.IndexByte(rest, '/')
+		if i == -1 {
+			// Relative, so it works under a base path. http.Redirect
+			// would make it absolute.
+			u := rest + "/"
+			if r.URL.RawQuery != "" {
+				u += "?" + r.URL.RawQuery
+			}
+			w.Header().Set("Location", u)
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+
+		f := LookupFortress(rest[:i])
+		if f == nil || f.Name == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), fortressKey{}, f))
+		http.StripPrefix("/f/"+f.Name, mux).ServeHTTP(w, r)
+	})
+}
+
+var fortressList = template.Must(template.New("fortresses").Parse(`<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<title>arm_ok</title>
+</head>
+<body>
+	<h1>Fortresses</h1>
+	<ul>
+	{{- range .Fortresses}}{{if .Name}}
+		<li><a href="{{$.Prefix}}{{.Name}}/{{$.Query}}">{{.Name}}</a></li>
+	{{- end}}{{end}}
+	</ul>
+</body>
+</html>
+`))
+
+// serveFortressList lists the named fortresses at / or /f/, passing along
+// the query string so any token or invite still applies.
+func serveFortressList(w http.ResponseWriter, r *http.Request) {
+	prefix := "f/"
+	if strings.HasPrefix(r.URL.Path, "/f/") {
+		prefix = ""
+	}
+
+	query := ""
+	if r.URL.RawQuery != "" {
+		query = "?" + r.URL.RawQuery
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = fortressList.Execute(w, &struct {
+		Fortresses []*Fortress
+		Prefix     string
+		Query      string
+	}{Fortresses, prefix, query})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func init() {
+	mux.HandleFunc("/healthz", serveHealth)
+	mux.HandleFunc("/readyz", serveReady)
+}
+
+// serveHealth reports that the process is up. It doesn't check DFHack.
+func serveHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+// serveReady reports whether clients can be served: the server isn't
+// shutting down, and every fortress (or the one in the path, for
+// /f/name/readyz) is connected to DFHack with a world loaded.
+func serveReady(w http.ResponseWriter, r *http.Request) {
+	fortresses := Fortresses
+	if f, ok := r.Context().Value(fortressKey{}).(*Fortress); ok {
+		fortresses = []*Fortress{f}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var buf strings.Builder
+	status := http.StatusOK
+	if Sessions.Draining() {
+		status = http.StatusServiceUnavailable
+		fmt.Fprintln(&buf, ErrShuttingDown)
+	}
+
+	for _, f := range fortresses {
+		name := f.Name
+		if name == "" {
+			name = "default"
+		}
+
+		if err := f.Ready(ctx); err != nil {
+			status = http.StatusServiceUnavailable
+			fmt.Fprintf(&buf, "%s: %v\n", name, err)
+		} else {
+			fmt.Fprintf(&buf, "%s: ok\n", name)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprint(w, buf.String())
+}
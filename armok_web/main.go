@@ -7,19 +7,81 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"flag"
+	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack/capture"
+	"github.com/BenLubar/arm_ok/dfhack/respcache"
 )
 
 var flagAddr = flag.String("addr", ":8050", "address to listen for HTTP connections on")
+var flagRecord = flag.String("record", "", "write the sessions with DFHack to this capture file (with several fortresses, replay one with dfreplay -upstream)")
+var flagMaxViewers = flag.Int("max-viewers", 0, "maximum number of clients connected at once (0 for no limit)")
+var flagIdleTimeout = flag.Duration("idle-timeout", 10*time.Minute, "disconnect clients that send no requests for this long (0 to never)")
+var flagUpstreamCalls = flag.Int("upstream-calls", 4, "maximum number of calls to DFHack in progress at once")
+var flagToken = flag.String("token", "", "shared token that lets clients connect as viewers")
+var flagControllerToken = flag.String("controller-token", "", "shared token that lets clients connect as controllers")
+var flagBasicAuth = flag.String("basic-auth", "", "file of name:hex-sha256-of-password:role lines for HTTP basic authentication")
+var flagInviteKey = flag.String("invite-key", "", "secret key for signing invite links")
+var flagInvite = flag.String("invite", "", "print an invite link for this role and exit (requires -invite-key)")
+var flagInviteTTL = flag.Duration("invite-ttl", 24*time.Hour, "how long links made with -invite work for")
+var flagPublic = flag.Bool("public", false, "let clients without credentials connect as viewers even if authentication is configured")
+var flagPolicy = flag.String("policy", "", "JSON file listing the methods clients may call (default: read-only for viewers)")
+var flagUpstreamQueue = flag.Int("upstream-queue", 32, "maximum number of client calls waiting for DFHack before more are rejected (0 for no limit)")
+var flagCacheFile = flag.String("cache-file", "", "file to keep static replies from DFHack in between runs")
+var flagPollInterval = flag.Duration("poll-interval", time.Second, "how often to update the map clients are looking at (0 to update only when asked)")
+var flagPollIdle = flag.Duration("poll-idle", time.Minute, "how long to keep updating the map after the last client stops looking at it (0 for no limit)")
+var flagPushInterval = flag.Duration("push-interval", 500*time.Millisecond, "how often to check subscriptions for changes to push to clients (0 to disable subscriptions)")
+var flagTLSCert = flag.String("tls-cert", "", "certificate file to serve HTTPS with (requires -tls-key)")
+var flagTLSKey = flag.String("tls-key", "", "private key file for -tls-cert")
+var flagTLSSelfSigned = flag.Bool("tls-self-signed", false, "serve HTTPS with a self-signed certificate, saved to -tls-cert and -tls-key if they are given and don't exist")
+var flagBasePath = flag.String("base-path", "/", "URL path armok_web is served under, for use behind a reverse proxy")
+var flagUpstream = flag.String("upstream", "", "host:port of DFHack to serve at the root (default: 127.0.0.1 and DFHACK_PORT, or none if -fortress is given)")
+var flagFortresses fortressFlag
+var flagShutdownTimeout = flag.Duration("shutdown-timeout", 10*time.Second, "how long to let clients finish their calls when shutting down")
+var flagMetricsAddr = flag.String("metrics-addr", "", "address to serve /metrics on without authentication, instead of beside the client behind it")
+
+func init() {
+	flag.Var(&flagFortresses, "fortress", "serve another DFHack under /f/name/, given as name=host:port (may be repeated)")
+}
+
+// fortressFlag collects the -fortress flags.
+type fortressFlag []string
+
+func (f *fortressFlag) String() string { return strings.Join(*f, " ") }
+
+func (f *fortressFlag) Set(s string) error {
+	if _, _, err := ParseFortress(s); err != nil {
+		return err
+	}
+	*f = append(*f, s)
+	return nil
+}
+
+// mux holds every handler armok_web serves. It is not http.DefaultServeMux,
+// which other packages (like expvar) add handlers to.
+var mux = http.NewServeMux()
 
-func handle(a asset) asset { http.Handle("/"+a.Name, a); return a }
+func handle(a asset) asset { mux.Handle("/"+a.Name, a); return a }
 
 func init() {
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" {
+			if requestFortress(r) == nil {
+				serveFortressList(w, r)
+				return
+			}
 			index.ServeHTTP(w, r)
 			return
 		}
@@ -27,16 +89,212 @@ func init() {
 	})
 }
 
+// recorder is set if the session with DFHack is being recorded. Each call
+// is flushed to the file as it completes, so it is never closed.
+var recorder *capture.Recorder
+
+func remoteDialSocket() func(context.Context, string) (io.ReadWriteCloser, error) {
+	if recorder == nil {
+		return nil
+	}
+	return recorder.DialSocket(nil)
+}
+
 func main() {
 	flag.Parse()
 
+	setupAuth()
+
+	policy := DefaultPolicy
+	if *flagPolicy != "" {
+		var err error
+		if policy, err = LoadPolicy(*flagPolicy); err != nil {
+			log.Fatalln("loading policy:", err)
+		}
+	}
+	ApplyPolicy(policy)
+
 	l, err := net.Listen("tcp", *flagAddr)
 	if err != nil {
 		log.Fatalln("listening failed:", err)
 	}
 	defer l.Close()
 
-	log.Printf("listening on http://%v/", l.Addr())
+	tlsConfig, err := loadTLSConfig()
+	if err != nil {
+		log.Fatalln("TLS:", err)
+	}
+	scheme := "http"
+	if tlsConfig != nil {
+		l = tls.NewListener(l, tlsConfig)
+		scheme = "https"
+	}
+
+	if *flagRecord != "" {
+		f, err := os.Create(*flagRecord)
+		if err != nil {
+			log.Fatalln("creating capture file:", err)
+		}
+		recorder = capture.NewRecorder(f)
+	}
+
+	setupFortresses()
+
+	Sessions = NewSessionManager()
+	Sessions.MaxSessions = *flagMaxViewers
+	Sessions.IdleTimeout = *flagIdleTimeout
+
+	PushInterval = *flagPushInterval
+
+	log.Printf("listening on %s://%v%s", scheme, l.Addr(), basePath())
+
+	if *flagMetricsAddr != "" {
+		ml, err := net.Listen("tcp", *flagMetricsAddr)
+		if err != nil {
+			log.Fatalln("listening for metrics failed:", err)
+		}
+		defer ml.Close()
+
+		log.Printf("serving metrics on http://%v/metrics", ml.Addr())
+
+		metricsMux := http.NewServeMux()
+		metricsMux.HandleFunc("/metrics", serveMetrics)
+		go func() {
+			log.Fatalln(http.Serve(ml, metricsMux))
+		}()
+	}
+
+	srv := &http.Server{Handler: rootHandler()}
+	go func() {
+		if err := srv.Serve(l); err != http.ErrServerClosed {
+			log.Fatalln(err)
+		}
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	log.Println("shutting down:", <-sig)
+	signal.Stop(sig)
+
+	shutdown(srv)
+}
+
+// shutdown stops accepting connections, lets the calls in progress finish
+// for up to -shutdown-timeout, and then disconnects from DFHack.
+func shutdown(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), *flagShutdownTimeout)
+	defer cancel()
+
+	// Websockets are hijacked, so the server doesn't wait for them;
+	// Drain does.
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Println("shutdown:", err)
+	}
+	if err := Sessions.Drain(ctx); err != nil {
+		log.Println("draining sessions:", err)
+	}
 
-	log.Fatalln(http.Serve(l, nil))
+	for _, f := range Fortresses {
+		f.Close()
+	}
+}
+
+// basePath returns -base-path with a slash at each end.
+func basePath() string {
+	p := *flagBasePath
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return p
+}
+
+// rootHandler serves everything registered on mux under
+// the base path. The page refers to everything else relative to itself, so
+// only the server needs to know the prefix.
+func rootHandler() http.Handler {
+	base := basePath()
+	if base == "/" {
+		return mux
+	}
+
+	root := http.NewServeMux()
+	root.Handle(base, http.StripPrefix(strings.TrimSuffix(base, "/"), mux))
+	root.HandleFunc(strings.TrimSuffix(base, "/"), func(w http.ResponseWriter, r *http.Request) {
+		// Keep the query string, which may hold a token or invite.
+		u := base
+		if r.URL.RawQuery != "" {
+			u += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, u, http.StatusMovedPermanently)
+	})
+	return root
+}
+
+func setupFortresses() {
+	if len(flagFortresses) == 0 || *flagUpstream != "" {
+		Fortresses = append(Fortresses, NewFortress("", *flagUpstream, *flagUpstreamCalls, *flagUpstreamQueue))
+	}
+	for _, s := range flagFortresses {
+		name, addr, _ := ParseFortress(s)
+		if LookupFortress(name) != nil {
+			log.Fatalln("fortress", name, "given twice")
+		}
+		Fortresses = append(Fortresses, NewFortress(name, addr, *flagUpstreamCalls, *flagUpstreamQueue))
+	}
+
+	for _, f := range Fortresses {
+		if *flagCacheFile != "" {
+			// Each fortress may have a different world loaded.
+			path := *flagCacheFile
+			if f.Name != "" {
+				path += "." + f.Name
+			}
+
+			var err error
+			if f.StaticCache, err = respcache.Open(path); err != nil {
+				log.Fatalln("loading cache:", err)
+			}
+		}
+
+		if *flagPollInterval > 0 {
+			f.Poller = &Poller{Fortress: f, Interval: *flagPollInterval, Idle: *flagPollIdle}
+		}
+	}
+}
+
+func setupAuth() {
+	if *flagToken != "" {
+		Authenticators = append(Authenticators, &TokenAuth{Token: *flagToken, Role: RoleViewer})
+	}
+	if *flagControllerToken != "" {
+		Authenticators = append(Authenticators, &TokenAuth{Token: *flagControllerToken, Role: RoleController})
+	}
+	if *flagBasicAuth != "" {
+		a, err := LoadBasicAuth(*flagBasicAuth)
+		if err != nil {
+			log.Fatalln("loading basic auth:", err)
+		}
+		Authenticators = append(Authenticators, a)
+	}
+	if *flagInviteKey != "" {
+		a := &InviteAuth{Key: []byte(*flagInviteKey)}
+		Authenticators = append(Authenticators, a)
+
+		if *flagInvite != "" {
+			role, err := ParseRole(*flagInvite)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("%s?invite=%s\n", basePath(), a.Invite(role, time.Now().Add(*flagInviteTTL)))
+			os.Exit(0)
+		}
+	} else if *flagInvite != "" {
+		log.Fatalln("-invite requires -invite-key")
+	}
+	if *flagPublic && len(Authenticators) != 0 {
+		Authenticators = append(Authenticators, AnonymousAuth(RoleViewer))
+	}
 }
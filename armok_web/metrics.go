@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+)
+
+// metrics holds the variables served at /metrics, by name. They aren't
+// published with expvar, whose handler also serves the command line (which
+// may hold secrets) and memory statistics.
+var metrics = make(map[string]expvar.Var)
+
+func newMap(name string) *expvar.Map {
+	m := new(expvar.Map).Init()
+	metrics[name] = m
+	return m
+}
+
+func newInt(name string) *expvar.Int {
+	v := new(expvar.Int)
+	metrics[name] = v
+	return v
+}
+
+var (
+	// DFHackCalls counts the calls made to DFHack, by method.
+	DFHackCalls = newMap("dfhack_calls")
+	// DFHackErrors counts failed calls, by CR_* code or "error" for
+	// failures that DFHack didn't report.
+	DFHackErrors = newMap("dfhack_errors")
+	// DFHackBinds counts method lookups that hit or missed the bind cache.
+	DFHackBinds = newMap("dfhack_binds")
+
+	// DFHackLatency, DFHackRequestSize, and DFHackResponseSize hold a
+	// histogram for each method.
+	DFHackLatency      = newMap("dfhack_call_seconds")
+	DFHackRequestSize  = newMap("dfhack_request_bytes")
+	DFHackResponseSize = newMap("dfhack_response_bytes")
+
+	// BlocksSent and BlocksSkipped count the blocks in GetBlockList
+	// replies and the blocks left out because the client already had
+	// them.
+	BlocksSent    = newInt("armok_web_blocks_sent")
+	BlocksSkipped = newInt("armok_web_blocks_skipped")
+)
+
+var (
+	latencyBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+	sizeBuckets    = []float64{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576}
+)
+
+func init() {
+	metrics["armok_web_clients"] = expvar.Func(func() interface{} {
+		if Sessions == nil {
+			return 0
+		}
+		return Sessions.Len()
+	})
+	metrics["armok_web_map_blocks"] = expvar.Func(func() interface{} {
+		n := 0
+		for _, f := range Fortresses {
+			n += f.MapCache.Len()
+		}
+		return n
+	})
+
+	// The counters cover every fortress, so they are only served at the
+	// root, and not at all if they have a listener of their own.
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(fortressKey{}).(*Fortress); ok || *flagMetricsAddr != "" {
+			http.NotFound(w, r)
+			return
+		}
+		requireAuth(http.HandlerFunc(serveMetrics)).ServeHTTP(w, r)
+	})
+}
+
+// serveMetrics writes the variables in metrics as a JSON object, like
+// expvar's handler.
+func serveMetrics(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	fmt.Fprint(w, "{\n")
+	for i, name := range names {
+		if i != 0 {
+			fmt.Fprint(w, ",\n")
+		}
+		fmt.Fprintf(w, "%q: %s", name, metrics[name])
+	}
+	fmt.Fprint(w, "\n}\n")
+}
+
+// upstreamStats records the calls made to DFHack.
+type upstreamStats struct{}
+
+func (upstreamStats) HandleBind(method, plugin string, cached bool) {
+	if cached {
+		DFHackBinds.Add("hit", 1)
+	} else {
+		DFHackBinds.Add("miss", 1)
+	}
+}
+
+func (upstreamStats) HandleCall(s *dfhack.CallStats) {
+	name := s.Method
+	if s.Plugin != "" {
+		name = s.Plugin + "::" + s.Method
+	}
+
+	DFHackCalls.Add(name, 1)
+	if s.Err != nil {
+		DFHackErrors.Add(errorName(s.Err), 1)
+	}
+
+	methodHistogram(DFHackLatency, name, latencyBuckets).Observe(s.End.Sub(s.Start).Seconds())
+	methodHistogram(DFHackRequestSize, name, sizeBuckets).Observe(float64(s.RequestSize))
+	if s.Err == nil {
+		methodHistogram(DFHackResponseSize, name, sizeBuckets).Observe(float64(s.ResponseSize))
+	}
+}
+
+var errorNames = []struct {
+	err  error
+	name string
+}{
+	{dfhack.ErrLinkFailure, "CR_LINK_FAILURE"},
+	{dfhack.ErrNeedsConsole, "CR_NEEDS_CONSOLE"},
+	{dfhack.ErrNotImplemented, "CR_NOT_IMPLEMENTED"},
+	{dfhack.ErrFailure, "CR_FAILURE"},
+	{dfhack.ErrWrongUsage, "CR_WRONG_USAGE"},
+	{dfhack.ErrNotFound, "CR_NOT_FOUND"},
+}
+
+func errorName(err error) string {
+	for _, e := range errorNames {
+		if errors.Is(err, e.err) {
+			return e.name
+		}
+	}
+
+	var rpcErr *dfhack.RPCError
+	if errors.As(err, &rpcErr) {
+		return "CR_" + strconv.Itoa(int(rpcErr.Code))
+	}
+
+	return "error"
+}
+
+var histogramLock sync.Mutex
+
+// methodHistogram returns the histogram for name in m, creating it if
+// needed.
+func methodHistogram(m *expvar.Map, name string, buckets []float64) *histogram {
+	histogramLock.Lock()
+	defer histogramLock.Unlock()
+
+	if h, ok := m.Get(name).(*histogram); ok {
+		return h
+	}
+
+	h := &histogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)+1),
+	}
+	m.Set(name, h)
+	return h
+}
+
+// histogram counts observations in buckets of increasing upper bounds. The
+// last count is for observations above every bound.
+type histogram struct {
+	mtx     sync.Mutex
+	buckets []float64
+	counts  []int64
+	count   int64
+	sum     float64
+}
+
+func (h *histogram) Observe(v float64) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	i := 0
+	for i < len(h.buckets) && v > h.buckets[i] {
+		i++
+	}
+	h.counts[i]++
+	h.count++
+	h.sum += v
+}
+
+// String returns the histogram as JSON, for expvar. Bucket counts are
+// cumulative, like Prometheus.
+func (h *histogram) String() string {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"count": %d, "sum": %v, "buckets": {`, h.count, h.sum)
+	var total int64
+	for i, n := range h.counts {
+		total += n
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		if i < len(h.buckets) {
+			fmt.Fprintf(&buf, `"%v": %d`, h.buckets[i], total)
+		} else {
+			fmt.Fprintf(&buf, `"+Inf": %d`, total)
+		}
+	}
+	buf.WriteString("}}")
+	return buf.String()
+}
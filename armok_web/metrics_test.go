@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get(p.HTTP.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/metrics", http.StatusUnauthorized},
+		{"/metrics?token=wrong", http.StatusUnauthorized},
+		{"/debug/vars", http.StatusNotFound},
+		{"/debug/vars?token=control", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		resp := get(tt.path)
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: got %s, want %d", tt.path, resp.Status, tt.status)
+		}
+	}
+
+	resp := get("/metrics?token=view")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("/metrics: got %s", resp.Status)
+	}
+
+	var vars map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		t.Fatal(err)
+	}
+	for name := range vars {
+		if _, ok := metrics[name]; !ok {
+			t.Errorf("/metrics served %q", name)
+		}
+	}
+	for name := range metrics {
+		if _, ok := vars[name]; !ok {
+			t.Errorf("/metrics left out %q", name)
+		}
+	}
+
+	*flagMetricsAddr = "127.0.0.1:0"
+	defer func() { *flagMetricsAddr = "" }()
+	resp = get("/metrics?token=view")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("/metrics with -metrics-addr: got %s", resp.Status)
+	}
+}
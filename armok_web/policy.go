@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+)
+
+// A Policy decides which methods the proxy forwards. For each method, the
+// first rule that matches it applies. Methods no rule matches are denied.
+type Policy struct {
+	Rules []*PolicyRule `json:"rules"`
+}
+
+// A PolicyRule applies to a method, or to every method of a plugin if
+// Method is "*". Core methods have an empty Plugin, so a rule with Method
+// "*" and no Plugin applies to every core method. RunCommand can't run any
+// commands under such a rule; give it a rule of its own first.
+type PolicyRule struct {
+	Plugin string `json:"plugin,omitempty"`
+	Method string `json:"method"`
+
+	// In and Out, if set, must match the method's signature, to catch
+	// policies written for a different version of DFHack.
+	In  string `json:"in,omitempty"`
+	Out string `json:"out,omitempty"`
+
+	// Action is "allow", "deny", or "limit". Limited methods are allowed
+	// at most Rate times per second on each fortress, in bursts of up to
+	// Burst calls, and at most ClientRate times per second from each
+	// client. Calls over either limit fail unless Delay is set, in which
+	// case they wait.
+	Action      string  `json:"action"`
+	Rate        float64 `json:"rate,omitempty"`
+	Burst       int     `json:"burst,omitempty"`
+	ClientRate  float64 `json:"client_rate,omitempty"`
+	ClientBurst int     `json:"client_burst,omitempty"`
+	Delay       bool    `json:"delay,omitempty"`
+
+	// Role is the role needed to call the method. It defaults to viewer.
+	Role string `json:"role,omitempty"`
+
+	// Commands lists the commands RunCommand may run, as path.Match
+	// patterns, except that * and ? match / too: "*" allows every command
+	// and "gui/*" every gui script. RunCommand can't be allowed without
+	// it.
+	Commands []string `json:"commands,omitempty"`
+
+	role Role
+}
+
+// DefaultPolicy is used if no policy file is given. Clients may read
+// anything RemoteFortressReader knows, and controllers may also change
+// the game.
+var DefaultPolicy = &Policy{Rules: []*PolicyRule{
+	{Method: "BindMethod", Action: "allow"},
+	{Method: "RunCommand", Action: "allow", Role: "controller", Commands: []string{"*"}},
+	{Method: "GetVersion", Action: "allow"},
+	{Method: "GetDFVersion", Action: "allow"},
+	{Method: "GetWorldInfo", Action: "allow"},
+	{Method: "ListEnums", Action: "allow"},
+	{Method: "ListJobSkills", Action: "allow"},
+	{Method: "ListMaterials", Action: "allow"},
+	{Method: "ListUnits", Action: "allow"},
+	{Method: "ListSquads", Action: "allow"},
+	{Method: "SetUnitLabors", Action: "allow", Role: "controller"},
+	{Plugin: pluginRemoteFortressReader, Method: "SetPauseState", Action: "allow", Role: "controller"},
+	{Plugin: pluginRemoteFortressReader, Method: "PassKeyboardEvent", Action: "allow", Role: "controller"},
+	{Plugin: pluginRemoteFortressReader, Method: "SendDigCommand", Action: "allow", Role: "controller"},
+	{Plugin: pluginRemoteFortressReader, Method: "MoveCommand", Action: "allow", Role: "controller"},
+	{Plugin: pluginRemoteFortressReader, Method: "*", Action: "allow"},
+}}
+
+// LoadPolicy reads a policy from a JSON file and checks it.
+func LoadPolicy(name string) (*Policy, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var p Policy
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	if err := p.Check(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	return &p, nil
+}
+
+// Check validates every rule against the known methods.
+func (p *Policy) Check() error {
+	for i, r := range p.Rules {
+		if err := r.check(); err != nil {
+			return fmt.Errorf("rule %d (%s::%s): %v", i+1, r.Plugin, r.Method, err)
+		}
+	}
+
+	if bind, _ := dfhack.LookupMethod("", "BindMethod"); p.Rule(bind) == nil {
+		return fmt.Errorf("BindMethod must be allowed")
+	}
+
+	return nil
+}
+
+func (r *PolicyRule) check() error {
+	if r.Method == "*" {
+		found := false
+		for _, m := range dfhack.Methods {
+			found = found || m.Plugin == r.Plugin
+		}
+		if !found {
+			return fmt.Errorf("unknown plugin %q", r.Plugin)
+		}
+		if r.In != "" || r.Out != "" {
+			return fmt.Errorf("signature given for every method of a plugin")
+		}
+	} else {
+		m, ok := dfhack.LookupMethod(r.Plugin, r.Method)
+		if !ok {
+			return fmt.Errorf("unknown method")
+		}
+		if (r.In != "" && r.In != m.In) || (r.Out != "" && r.Out != m.Out) {
+			return fmt.Errorf("signature %q -> %q does not match %q -> %q", r.In, r.Out, m.In, m.Out)
+		}
+	}
+
+	var err error
+	r.role = RoleViewer
+	if r.Role != "" {
+		if r.role, err = ParseRole(r.Role); err != nil {
+			return err
+		}
+	}
+
+	isRunCommand := r.Plugin == "" && r.Method == "RunCommand"
+	if len(r.Commands) != 0 && !isRunCommand {
+		return fmt.Errorf("commands given for a method other than RunCommand")
+	}
+	for _, pattern := range r.Commands {
+		if _, err := matchCommand(pattern, ""); err != nil {
+			return fmt.Errorf("command pattern %q: %v", pattern, err)
+		}
+	}
+
+	switch r.Action {
+	case "deny":
+		if r.Plugin == "" && r.Method == "BindMethod" {
+			return fmt.Errorf("BindMethod can't be denied")
+		}
+		return nil
+	case "allow":
+	case "limit":
+		if r.Rate < 0 || r.ClientRate < 0 || (r.Rate == 0 && r.ClientRate == 0) {
+			return fmt.Errorf("limit without a positive rate")
+		}
+		if r.Burst < 1 {
+			r.Burst = 1
+		}
+		if r.ClientBurst < 1 {
+			r.ClientBurst = 1
+		}
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+
+	if isRunCommand && len(r.Commands) == 0 {
+		return fmt.Errorf("RunCommand allowed without any commands")
+	}
+
+	return nil
+}
+
+// Rule returns the rule for m, or nil if m is denied.
+func (p *Policy) Rule(m dfhack.Method) *PolicyRule {
+	for _, r := range p.Rules {
+		if r.Plugin != m.Plugin || (r.Method != m.Name && r.Method != "*") {
+			continue
+		}
+		if r.Action == "deny" {
+			return nil
+		}
+		return r
+	}
+	return nil
+}
+
+// AllowsCommand reports whether RunCommand may run command under r.
+func (r *PolicyRule) AllowsCommand(command string) bool {
+	for _, pattern := range r.Commands {
+		if ok, _ := matchCommand(pattern, command); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCommand is path.Match with / treated like any other character, since
+// script names such as gui/gm-editor are a single command.
+func matchCommand(pattern, command string) (bool, error) {
+	const sep = "\x00"
+	return path.Match(strings.Replace(pattern, "/", sep, -1), strings.Replace(command, "/", sep, -1))
+}
+
+// ApplyPolicy rebuilds AllowedMessages from p, which must have been
+// checked. It must be called before any client connects.
+func ApplyPolicy(p *Policy) {
+	AllowedMessages = AllowedMessages[:0]
+	CoreMessages = make(map[string]int16)
+	PluginMessages = make(map[string]map[string]int16)
+
+	for _, m := range dfhack.Methods {
+		rule := p.Rule(m)
+
+		// Clients expect RunCommand to have a fixed id, so it is kept
+		// even when it is denied.
+		if rule == nil && !(m.Plugin == "" && m.Name == "RunCommand") {
+			continue
+		}
+
+		handle, ok := ProxyHandlers[[2]string{m.Plugin, m.Name}]
+		if !ok {
+			handle = forwardMethod(m)
+		}
+
+		id := int16(len(AllowedMessages))
+		AllowedMessages = append(AllowedMessages, struct {
+			dfhack.Method
+			Rule   *PolicyRule
+			Handle func(*proxy_ctx) error
+		}{m, rule, handle})
+
+		if m.Plugin == "" {
+			CoreMessages[m.Name] = id
+		} else {
+			msgs, ok := PluginMessages[m.Plugin]
+			if !ok {
+				msgs = make(map[string]int16)
+				PluginMessages[m.Plugin] = msgs
+			}
+			msgs[m.Name] = id
+		}
+	}
+
+	if CoreMessages["BindMethod"] != 0 || CoreMessages["RunCommand"] != 1 {
+		panic("armok_web: BindMethod and RunCommand must be the first two methods")
+	}
+}
+
+func init() {
+	if err := DefaultPolicy.Check(); err != nil {
+		panic("armok_web: DefaultPolicy: " + err.Error())
+	}
+}
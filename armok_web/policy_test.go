@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+)
+
+func TestPolicyRule(t *testing.T) {
+	p := &Policy{Rules: []*PolicyRule{
+		{Method: "RunCommand", Action: "allow", Role: "controller", Commands: []string{"ls", "die*", "gui/*"}},
+		{Method: "GetDFVersion", Action: "deny"},
+		{Method: "*", Action: "allow"},
+		{Plugin: pluginRemoteFortressReader, Method: "SetPauseState", Action: "allow", Role: "controller"},
+		{Plugin: pluginRemoteFortressReader, Method: "GetBlockList", Action: "limit", Rate: 10},
+		{Plugin: pluginRemoteFortressReader, Method: "*", Action: "allow"},
+	}}
+	if err := p.Check(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		plugin, method string
+		want           int // index of the rule, or -1 if denied
+	}{
+		{"", "BindMethod", 2},
+		{"", "RunCommand", 0},
+		{"", "GetVersion", 2},
+		{"", "GetDFVersion", -1},
+		{pluginRemoteFortressReader, "SetPauseState", 3},
+		{pluginRemoteFortressReader, "GetBlockList", 4},
+		{pluginRemoteFortressReader, "GetMapInfo", 5},
+	}
+
+	for _, tt := range tests {
+		m, ok := dfhack.LookupMethod(tt.plugin, tt.method)
+		if !ok {
+			t.Fatalf("no method %s::%s", tt.plugin, tt.method)
+		}
+
+		var want *PolicyRule
+		if tt.want >= 0 {
+			want = p.Rules[tt.want]
+		}
+		if got := p.Rule(m); got != want {
+			t.Errorf("%s::%s: got rule %+v, want %+v", tt.plugin, tt.method, got, want)
+		}
+	}
+
+	if r := p.Rules[3]; r.role != RoleController {
+		t.Errorf("SetPauseState: role %v", r.role)
+	}
+
+	for _, tt := range []struct {
+		command string
+		want    bool
+	}{
+		{"ls", true},
+		{"die", true},
+		{"die-now", true},
+		{"lsx", false},
+		{"", false},
+		{"gui/gm-editor", true},
+		{"gui", false},
+		{"devel/query", false},
+	} {
+		if got := p.Rules[0].AllowsCommand(tt.command); got != tt.want {
+			t.Errorf("AllowsCommand(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+
+	// The default policy allows scripts in subdirectories.
+	for _, r := range DefaultPolicy.Rules {
+		if r.Method != "RunCommand" {
+			continue
+		}
+		for _, command := range []string{"ls", "gui/gm-editor", "devel/query"} {
+			if !r.AllowsCommand(command) {
+				t.Errorf("default policy: AllowsCommand(%q) = false", command)
+			}
+		}
+	}
+
+	// RunCommand matched by "*" runs nothing.
+	if (&PolicyRule{Method: "*", Action: "allow"}).AllowsCommand("ls") {
+		t.Error("a core wildcard rule allows commands")
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	bind := &PolicyRule{Method: "BindMethod", Action: "allow"}
+
+	tests := []struct {
+		name  string
+		rules []*PolicyRule
+		ok    bool
+	}{
+		{"default", DefaultPolicy.Rules, true},
+		{"core wildcard", []*PolicyRule{{Method: "*", Action: "allow"}}, true},
+		{"no BindMethod", []*PolicyRule{{Method: "GetVersion", Action: "allow"}}, false},
+		{"BindMethod denied by wildcard", []*PolicyRule{{Method: "*", Action: "deny"}, bind}, false},
+		{"BindMethod denied", []*PolicyRule{{Method: "BindMethod", Action: "deny"}}, false},
+		{"unknown method", []*PolicyRule{bind, {Method: "Frobnicate", Action: "allow"}}, false},
+		{"unknown plugin", []*PolicyRule{bind, {Plugin: "Frobnicator", Method: "*", Action: "allow"}}, false},
+		{"wrong signature", []*PolicyRule{bind, {Method: "GetVersion", Out: "dfproto.IntMessage", Action: "allow"}}, false},
+		{"unknown action", []*PolicyRule{bind, {Method: "GetVersion", Action: "maybe"}}, false},
+		{"unknown role", []*PolicyRule{bind, {Method: "GetVersion", Action: "allow", Role: "king"}}, false},
+		{"limit without rate", []*PolicyRule{bind, {Method: "GetVersion", Action: "limit"}}, false},
+		{"RunCommand without commands", []*PolicyRule{bind, {Method: "RunCommand", Action: "allow"}}, false},
+		{"commands for another method", []*PolicyRule{bind, {Method: "GetVersion", Action: "allow", Commands: []string{"ls"}}}, false},
+		{"bad command pattern", []*PolicyRule{bind, {Method: "RunCommand", Action: "allow", Commands: []string{"["}}}, false},
+	}
+
+	for _, tt := range tests {
+		err := (&Policy{Rules: tt.rules}).Check()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: got %v, want ok = %v", tt.name, err, tt.ok)
+		}
+	}
+}
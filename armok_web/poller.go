@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+)
+
+// A Poller keeps a fortress's MapCache up to date with the areas clients
+// are looking at, so that GetBlockList can be answered from the cache no
+// matter how many clients are connected.
+type Poller struct {
+	Fortress *Fortress
+	Interval time.Duration
+
+	// Idle is how long the last areas are kept up to date after nobody
+	// is looking at them. After that, nothing is polled until a client
+	// asks for blocks again. Zero means no limit.
+	Idle time.Duration
+
+	mtx     sync.Mutex
+	areas   []*RemoteFortressReader.BlockRequest
+	polled  time.Time
+	watched time.Time // the last time a client had a view
+	mapX    *int32
+	mapY    *int32
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{} // closed when Run returns
+	running  bool          // guarded by mtx
+}
+
+// Run polls until Stop is called. Nothing is polled while the fortress is
+// disconnected.
+func (p *Poller) Run() {
+	stop := p.stopped()
+	defer close(p.done)
+
+	p.mtx.Lock()
+	p.running = true
+	p.mtx.Unlock()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t := time.NewTicker(p.Interval)
+	defer t.Stop()
+
+	busy := make(chan struct{}, 1)
+	for {
+		select {
+		case <-t.C:
+		case <-stop:
+			return
+		}
+
+		select {
+		case busy <- struct{}{}:
+		default:
+			// The last poll is still going. Let it finish rather
+			// than asking DFHack for the same blocks again.
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-busy }()
+
+			if err := p.poll(ctx); err != nil && !errors.Is(err, ErrNotConnected) && err != context.Canceled {
+				log.Println("map poll:", err)
+			}
+		}()
+	}
+}
+
+// Stop ends Run and waits for it to return, if it was running.
+func (p *Poller) Stop() {
+	close(p.stopped())
+
+	p.mtx.Lock()
+	running := p.running
+	p.mtx.Unlock()
+	if running {
+		<-p.done
+	}
+}
+
+func (p *Poller) stopped() chan struct{} {
+	p.stopOnce.Do(func() {
+		p.stop = make(chan struct{})
+		p.done = make(chan struct{})
+	})
+	return p.stop
+}
+
+func (p *Poller) poll(ctx context.Context) error {
+	f := p.Fortress
+
+	areas := pollAreas(Sessions.Views(f))
+	p.mtx.Lock()
+	switch {
+	case len(areas) != 0:
+		p.watched = time.Now()
+	case p.Idle == 0 || time.Since(p.watched) <= p.Idle:
+		// Nobody is watching. Keep the last areas warm for a while
+		// in case they come back.
+		areas = p.areas
+	}
+	p.mtx.Unlock()
+	if len(areas) == 0 {
+		return nil
+	}
+
+	var mapX, mapY *int32
+	for _, area := range areas {
+		var resp *RemoteFortressReader.BlockList
+		err := f.Background(ctx, func(ctx context.Context, remote *dfhack.Conn) (err error) {
+			resp, _, err = remote.GetBlockListContext(ctx, area)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		f.MapCache.ApplyList(resp)
+		mapX, mapY = resp.MapX, resp.MapY
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.areas = areas
+	p.polled = time.Now()
+	p.mapX, p.mapY = mapX, mapY
+
+	return nil
+}
+
+// Covers returns the map position from the last poll if it included all of
+// req and is recent enough to answer req from MapCache.
+func (p *Poller) Covers(req *RemoteFortressReader.BlockRequest) (mapX, mapY *int32, ok bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if time.Since(p.polled) > p.Interval*2 {
+		return nil, nil, false
+	}
+
+	for _, a := range p.areas {
+		if req.GetMinX() >= a.GetMinX() && req.GetMaxX() <= a.GetMaxX() &&
+			req.GetMinY() >= a.GetMinY() && req.GetMaxY() <= a.GetMaxY() &&
+			req.GetMinZ() >= a.GetMinZ() && req.GetMaxZ() <= a.GetMaxZ() {
+			return p.mapX, p.mapY, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// pollAreas returns the areas to poll to cover every view. Views are
+// polled together where that asks for no more blocks than polling them
+// separately would, so clients looking at the same part of the map share a
+// poll, but clients far apart don't make the poller fetch everything in
+// between.
+func pollAreas(views []*RemoteFortressReader.BlockRequest) []*RemoteFortressReader.BlockRequest {
+	var areas []*RemoteFortressReader.BlockRequest
+
+	for _, v := range views {
+		merged := false
+		for i, a := range areas {
+			if u := unionArea(a, v); volume(u) <= volume(a)+volume(v) {
+				areas[i] = u
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			areas = append(areas, unionArea(v, v))
+		}
+	}
+
+	return areas
+}
+
+// volume returns the number of blocks in an area.
+func volume(a *RemoteFortressReader.BlockRequest) int64 {
+	dx := int64(a.GetMaxX()) - int64(a.GetMinX())
+	dy := int64(a.GetMaxY()) - int64(a.GetMinY())
+	dz := int64(a.GetMaxZ()) - int64(a.GetMinZ())
+	if dx <= 0 || dy <= 0 || dz <= 0 {
+		return 0
+	}
+	return dx * dy * dz
+}
+
+// unionArea returns the smallest area containing a and b.
+func unionArea(a, b *RemoteFortressReader.BlockRequest) *RemoteFortressReader.BlockRequest {
+	minX, maxX := a.GetMinX(), a.GetMaxX()
+	minY, maxY := a.GetMinY(), a.GetMaxY()
+	minZ, maxZ := a.GetMinZ(), a.GetMaxZ()
+	if b.GetMinX() < minX {
+		minX = b.GetMinX()
+	}
+	if b.GetMaxX() > maxX {
+		maxX = b.GetMaxX()
+	}
+	if b.GetMinY() < minY {
+		minY = b.GetMinY()
+	}
+	if b.GetMaxY() > maxY {
+		maxY = b.GetMaxY()
+	}
+	if b.GetMinZ() < minZ {
+		minZ = b.GetMinZ()
+	}
+	if b.GetMaxZ() > maxZ {
+		maxZ = b.GetMaxZ()
+	}
+
+	return &RemoteFortressReader.BlockRequest{
+		MinX: &minX,
+		MaxX: &maxX,
+		MinY: &minY,
+		MaxY: &maxY,
+		MinZ: &minZ,
+		MaxZ: &maxZ,
+	}
+}
+
+// fetchBlocks updates the fortress's MapCache with the blocks in req, unless
+// the poller already has them, and returns the map position to send with
+// them.
+func fetchBlocks(ctx *proxy_ctx, req *RemoteFortressReader.BlockRequest) (mapX, mapY *int32, text []*dfproto.CoreTextNotification, err error) {
+	f := ctx.session.Fortress
+
+	Sessions.SetView(ctx.session, req)
+
+	if f.Poller != nil {
+		if mapX, mapY, ok := f.Poller.Covers(req); ok {
+			return mapX, mapY, nil, nil
+		}
+	}
+
+	// The poller will pick up this area next time; until then, the
+	// client waits for it like any other call.
+	err = ctx.Upstream(func(c context.Context, remote *dfhack.Conn) error {
+		resp, text1, err := remote.GetBlockListContext(c, req)
+		text = text1
+		if err != nil {
+			return err
+		}
+
+		f.MapCache.ApplyList(resp)
+		mapX, mapY = resp.MapX, resp.MapY
+		return nil
+	})
+
+	return
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/golang/protobuf/proto"
+)
+
+func area(minX, maxX, minY, maxY, minZ, maxZ int32) *RemoteFortressReader.BlockRequest {
+	return &RemoteFortressReader.BlockRequest{
+		MinX: proto.Int32(minX), MaxX: proto.Int32(maxX),
+		MinY: proto.Int32(minY), MaxY: proto.Int32(maxY),
+		MinZ: proto.Int32(minZ), MaxZ: proto.Int32(maxZ),
+	}
+}
+
+func TestPollAreas(t *testing.T) {
+	tests := []struct {
+		name  string
+		views []*RemoteFortressReader.BlockRequest
+		want  []*RemoteFortressReader.BlockRequest
+	}{
+		{"no views", nil, nil},
+		{"one view", []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+		}, []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+		}},
+		{"same view", []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+			area(0, 4, 0, 4, 0, 10),
+		}, []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+		}},
+		{"overlapping views", []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+			area(1, 5, 0, 4, 0, 10),
+		}, []*RemoteFortressReader.BlockRequest{
+			area(0, 5, 0, 4, 0, 10),
+		}},
+		{"far apart", []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+			area(100, 104, 100, 104, 0, 10),
+			area(1, 4, 1, 4, 2, 8),
+		}, []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+			area(100, 104, 100, 104, 0, 10),
+		}},
+		{"different levels", []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+			area(0, 4, 0, 4, 100, 110),
+		}, []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+			area(0, 4, 0, 4, 100, 110),
+		}},
+	}
+
+	for _, tt := range tests {
+		got := pollAreas(tt.views)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !proto.Equal(got[i], tt.want[i]) {
+				t.Errorf("%s: area %d: got %v, want %v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestPollerCovers(t *testing.T) {
+	p := &Poller{
+		Interval: time.Minute,
+		areas: []*RemoteFortressReader.BlockRequest{
+			area(0, 4, 0, 4, 0, 10),
+			area(100, 104, 100, 104, 0, 10),
+		},
+		polled: time.Now(),
+		mapX:   proto.Int32(7),
+	}
+
+	tests := []struct {
+		req  *RemoteFortressReader.BlockRequest
+		want bool
+	}{
+		{area(0, 4, 0, 4, 0, 10), true},
+		{area(1, 3, 1, 3, 2, 8), true},
+		{area(101, 103, 100, 104, 0, 10), true},
+		{area(0, 5, 0, 4, 0, 10), false},
+		{area(0, 104, 0, 104, 0, 10), false},
+	}
+	for _, tt := range tests {
+		mapX, _, ok := p.Covers(tt.req)
+		if ok != tt.want || (ok && mapX != p.mapX) {
+			t.Errorf("Covers(%v): got %v, want %v", tt.req, ok, tt.want)
+		}
+	}
+
+	p.polled = time.Now().Add(-time.Hour)
+	if _, _, ok := p.Covers(area(0, 4, 0, 4, 0, 10)); ok {
+		t.Error("an old poll covers a request")
+	}
+}
+
+func TestPollerSlow(t *testing.T) {
+	tp := newTestProxy(t)
+	defer tp.Close()
+
+	// Much slower than the interval, and than the timeout polls used to
+	// have.
+	const delay = 50 * time.Millisecond
+	tp.DFHack.Handle("RemoteFortressReader", "GetBlockList", func(proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{
+			Result: &RemoteFortressReader.BlockList{MapBlocks: []*RemoteFortressReader.MapBlock{testBlock(1, 1, 1, 10)}},
+			Delay:  delay,
+		}
+	})
+
+	f := Fortresses[0]
+	f.Poller = &Poller{Fortress: f, Interval: delay / 10}
+
+	s, err := Sessions.Open(context.Background(), "test", RoleViewer, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Sessions.Close(s)
+	Sessions.SetView(s, area(0, 4, 0, 4, 0, 4))
+
+	f.Connect()
+
+	// Only the poller calls DFHack, so any more than one call at a time
+	// means polls piled up.
+	running := func() int {
+		f.upstream.mtx.Lock()
+		defer f.upstream.mtx.Unlock()
+		return f.upstream.running + len(f.upstream.waiting)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for f.MapCache.Len() == 0 {
+		if n := running(); n > 1 {
+			t.Fatalf("%d polls at once", n)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("a poll slower than the interval never finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for end := time.Now().Add(4 * delay); time.Now().Before(end); time.Sleep(time.Millisecond) {
+		if n := running(); n > 1 {
+			t.Fatalf("%d polls at once", n)
+		}
+	}
+
+	f.Poller.mtx.Lock()
+	defer f.Poller.mtx.Unlock()
+	if got := f.Poller.areas; len(got) != 1 || !proto.Equal(got[0], area(0, 4, 0, 4, 0, 4)) {
+		t.Errorf("polled %v", got)
+	}
+}
+
+func TestPollerIdle(t *testing.T) {
+	tp := newTestProxy(t)
+	defer tp.Close()
+
+	var mtx sync.Mutex
+	calls := 0
+	tp.DFHack.Handle("RemoteFortressReader", "GetBlockList", func(proto.Message) dfhacktest.Reply {
+		mtx.Lock()
+		calls++
+		mtx.Unlock()
+		return dfhacktest.Reply{Result: &RemoteFortressReader.BlockList{}}
+	})
+	polled := func() int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return calls
+	}
+
+	const idle = 50 * time.Millisecond
+	f := Fortresses[0]
+	f.Poller = &Poller{Fortress: f, Interval: time.Millisecond, Idle: idle}
+
+	watch := func() *Session {
+		s, err := Sessions.Open(context.Background(), "test", RoleViewer, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		Sessions.SetView(s, area(0, 4, 0, 4, 0, 4))
+		return s
+	}
+	waitForPoll := func(what string) {
+		n := polled()
+		for deadline := time.Now().Add(5 * time.Second); polled() == n; time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatal("no poll", what)
+			}
+		}
+	}
+
+	s := watch()
+	f.Connect()
+	waitForPoll("while watched")
+
+	// The area is kept warm for a while after the client leaves, then
+	// left alone.
+	Sessions.Close(s)
+	waitForPoll("just after the client left")
+	time.Sleep(idle + 20*time.Millisecond)
+	n := polled()
+	time.Sleep(idle)
+	if m := polled(); m != n {
+		t.Errorf("%d polls with nobody watching", m-n)
+	}
+
+	s = watch()
+	defer Sessions.Close(s)
+	waitForPoll("after a client came back")
+}
@@ -2,17 +2,20 @@ package main
 
 import (
 	"bytes"
+	"compress/flate"
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
-	"hash/adler32"
 	"io"
 	"log"
 	"net/http"
-	"sync"
+	"time"
 
 	"github.com/BenLubar/arm_ok/dfhack"
 	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
 	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/BenLubar/arm_ok/dfhack/mapcache"
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/websocket"
 )
@@ -57,7 +60,18 @@ type proxy_ctx struct {
 	b []byte
 	w io.Writer
 
-	hashes map[[3]int32]uint32
+	session *Session
+
+	// If ack is set, a successful reply acknowledges a subscription and
+	// its result is kept in acked.
+	ack   bool
+	acked []byte
+}
+
+// Upstream calls fn once it is this client's turn to use its fortress's
+// connection to DFHack.
+func (ctx *proxy_ctx) Upstream(fn func(context.Context, *dfhack.Conn) error) error {
+	return Sessions.Upstream(ctx.session, fn)
 }
 
 func (ctx *proxy_ctx) ReadMessage(req proto.Message) error {
@@ -65,10 +79,14 @@ func (ctx *proxy_ctx) ReadMessage(req proto.Message) error {
 }
 
 func (ctx *proxy_ctx) writeHeader(id int16, size int32) error {
-	return binary.Write(ctx.w, binary.LittleEndian, &rpcMessageHeader{
+	header := rpcMessageHeader{
 		ID:   id,
 		Size: size,
-	})
+	}
+	if id == rpcReplyResult && ctx.ack {
+		header.Pad[0] = padSubscribe
+	}
+	return binary.Write(ctx.w, binary.LittleEndian, &header)
 }
 
 func (ctx *proxy_ctx) WriteText(text *dfproto.CoreTextNotification) error {
@@ -115,6 +133,9 @@ func (ctx *proxy_ctx) WriteMessage(resp proto.Message) error {
 	if err := ctx.writeHeader(rpcReplyResult, int32(len(b))); err != nil {
 		return err
 	}
+	if ctx.ack {
+		ctx.acked = b
+	}
 
 	n, err := ctx.w.Write(b)
 	if err == nil && n != len(b) {
@@ -134,22 +155,27 @@ func (ctx *proxy_ctx) Respond(resp proto.Message, text []*dfproto.CoreTextNotifi
 
 func (ctx *proxy_ctx) RespondPartial(text []*dfproto.CoreTextNotification, err error) (bool, error) {
 	var errno int32
-	switch err {
-	case dfhack.ErrLinkFailure:
-		errno = cr_link_failure
-	case dfhack.ErrNeedsConsole:
-		errno = cr_needs_console
-	case dfhack.ErrNotImplemented:
-		errno = cr_not_implemented
-	case dfhack.ErrFailure:
-		errno = cr_failure
-	case dfhack.ErrWrongUsage:
-		errno = cr_wrong_usage
-	case dfhack.ErrNotFound:
-		errno = cr_not_found
-	case nil:
-	default:
+	var rpcErr *dfhack.RPCError
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNotConnected):
+		// Checked first, since the reason may be an RPCError from
+		// one of the calls made while connecting.
+		var linkErr *linkError
+		if errors.As(err, &linkErr) {
+			return false, ctx.WriteError(cr_link_failure, fmt.Sprintf("DFHack is not reachable (%v); retrying.\n", linkErr.err))
+		}
+		return false, ctx.WriteError(cr_link_failure, "DFHack is not reachable; retrying.\n")
+	case errors.As(err, &rpcErr):
+		// forward the code as-is, even if we don't know what it means.
+		errno = rpcErr.Code
+	case err == ErrUpstreamBusy:
+		return false, ctx.WriteError(cr_failure, "DFHack is busy; try again later.\n")
+	case err == context.Canceled || err == context.DeadlineExceeded || err == ErrClosed:
 		return false, err
+	default:
+		// The connection to DFHack failed during the call.
+		return false, ctx.WriteError(cr_link_failure, fmt.Sprintf("Lost the connection to DFHack (%v); reconnecting.\n", err))
 	}
 
 	for _, t := range text {
@@ -164,374 +190,100 @@ func (ctx *proxy_ctx) RespondPartial(text []*dfproto.CoreTextNotification, err e
 	return false, ctx.WriteError(errno, "")
 }
 
-type MapBlock struct {
-	Block *RemoteFortressReader.MapBlock
-	Hash  uint32
-}
-
 var (
 	pluginRemoteFortressReader = "RemoteFortressReader"
 
-	mapBlocks = make(map[[3]int32]MapBlock)
-	mapLock   sync.Mutex
-	mapOnce   sync.Once
-
-	CoreMessages    = make(map[string]int16)
-	PluginMessages  = make(map[string]map[string]int16)
-	AllowedMessages = []struct {
-		Command string
-		Plugin  *string
-		In, Out string
-		Handle  func(*proxy_ctx) error
-	}{
-		{
-			Command: "BindMethod",
-			In:      "dfproto.CoreBindRequest",
-			Out:     "dfproto.CoreBindReply",
-			Handle:  nil, // assigned below
-		},
-		{
-			Command: "RunCommand",
-			In:      "dfproto.CoreRunCommandRequest",
-			Out:     "dfproto.EmptyMessage",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.CoreRunCommandRequest
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				return ctx.WriteError(cr_not_implemented, "")
-			},
-		},
-		{
-			Command: "GetVersion",
-			In:      "dfproto.EmptyMessage",
-			Out:     "dfproto.StringMessage",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				return ctx.WriteMessage(RemoteVersion)
-			},
-		},
-		{
-			Command: "GetDFVersion",
-			In:      "dfproto.EmptyMessage",
-			Out:     "dfproto.StringMessage",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				return ctx.WriteMessage(RemoteDFVersion)
-			},
-		},
-		{
-			Command: "GetWorldInfo",
-			In:      "dfproto.EmptyMessage",
-			Out:     "dfproto.GetWorldInfoOut",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetWorldInfo()
-				return ctx.Respond(resp, text, err)
-			},
-		},
-		{
-			Command: "ListEnums",
-			In:      "dfproto.EmptyMessage",
-			Out:     "dfproto.ListEnumsOut",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.ListEnums()
-				return ctx.Respond(resp, text, err)
-			},
-		},
-		{
-			Command: "ListJobSkills",
-			In:      "dfproto.EmptyMessage",
-			Out:     "dfproto.ListJobSkillsOut",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.ListJobSkills()
-				return ctx.Respond(resp, text, err)
-			},
-		},
-		{
-			Command: "ListMaterials",
-			In:      "dfproto.ListMaterialsIn",
-			Out:     "dfproto.ListMaterialsOut",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.ListMaterialsIn
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.ListMaterials(&req)
-				return ctx.Respond(resp, text, err)
-			},
-		},
-		{
-			Command: "GetGrowthList",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "dfproto.EmptyMessage",
-			Out:     "RemoteFortressReader.MaterialList",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetGrowthList()
-				return ctx.Respond(resp, text, err)
-			},
-		},
-		{
-			Command: "GetMaterialList",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "dfproto.EmptyMessage",
-			Out:     "RemoteFortressReader.MaterialList",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetMaterialList()
-				return ctx.Respond(resp, text, err)
-			},
-		},
-		{
-			Command: "GetTiletypeList",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "dfproto.EmptyMessage",
-			Out:     "RemoteFortressReader.TiletypeList",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetTiletypeList()
-				return ctx.Respond(resp, text, err)
-			},
-		},
-		{
-			Command: "ResetMapHashes",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "dfproto.EmptyMessage",
-			Out:     "dfproto.EmptyMessage",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				ctx.hashes = make(map[[3]int32]uint32)
-
-				return ctx.WriteMessage(&dfproto.EmptyMessage{})
-			},
-		},
-		{
-			Command: "GetBlockList",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "RemoteFortressReader.BlockRequest",
-			Out:     "RemoteFortressReader.BlockList",
-			Handle: func(ctx *proxy_ctx) error {
-				var req RemoteFortressReader.BlockRequest
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				mapLock.Lock()
-				defer mapLock.Unlock()
-
-				limit := req.BlocksNeeded
-				req.BlocksNeeded = nil
-				resp, text, err := Remote.GetBlockList(&req)
-				if ok, err1 := ctx.RespondPartial(text, err); !ok {
-					return err1
-				}
-				req.BlocksNeeded = limit
-
-				for _, block := range resp.MapBlocks {
-					pos := [3]int32{block.GetMapX() / 16, block.GetMapY() / 16, block.GetMapZ()}
-					if old, ok := mapBlocks[pos]; ok {
-						if len(block.Magma) == 0 {
-							block.Magma = old.Block.Magma
-						}
-						if len(block.Water) == 0 {
-							block.Water = old.Block.Water
-						}
-						if len(block.Tiles) == 0 {
-							block.Tiles = old.Block.Tiles
-						}
-						if len(block.Materials) == 0 {
-							block.Materials = old.Block.Materials
-						}
-						if len(block.LayerMaterials) == 0 {
-							block.LayerMaterials = old.Block.LayerMaterials
-						}
-						if len(block.VeinMaterials) == 0 {
-							block.VeinMaterials = old.Block.VeinMaterials
-						}
-						if len(block.BaseMaterials) == 0 {
-							block.BaseMaterials = old.Block.BaseMaterials
-						}
-					}
-					b, err := proto.Marshal(block)
-					if err != nil {
-						// should never happen
-						panic(err)
-					}
-					mapBlocks[pos] = MapBlock{
-						Block: block,
-						Hash:  adler32.Checksum(b),
-					}
-				}
-
-				resp1 := &RemoteFortressReader.BlockList{
-					MapX: resp.MapX,
-					MapY: resp.MapY,
-				}
-
-				min_x, max_x := req.GetMinX(), req.GetMaxX()
-				min_y, max_y := req.GetMinY(), req.GetMaxY()
-				min_z, max_z := req.GetMinZ(), req.GetMaxZ()
-				center_x := (min_x + max_x) / 2
-				center_y := (min_y + max_y) / 2
-				number_of_points := ((max_x - center_x + 1) * 2) * ((max_y - center_y + 1) * 2)
-				var blocks_needed, blocks_sent int32
-				if req.BlocksNeeded != nil {
-					blocks_needed = req.GetBlocksNeeded()
-				} else {
-					blocks_needed = number_of_points * (max_z - min_z)
-				}
-				for zz := max_z - 1; zz >= min_z; zz-- {
-					// (di, dj) is a vector - direction in which we move right now
-					var di, dj int32 = 1, 0
-					// length of current segment
-					var segment_length, segment_passed int32 = 1, 0
-					// current position (i, j) and how much of current segment we passed
-					var i, j, k int32 = center_x, center_y, 0
-					for k = 0; k < number_of_points; k++ {
-						if blocks_sent >= blocks_needed {
-							break
-						}
-						if i >= min_x && i < max_x && j >= min_y && j < max_y {
-							pos := [3]int32{i, j, zz}
-							if block, ok := mapBlocks[pos]; ok {
-								// if ctx.hashes is nil, the client hasn't called ResetMapHashes yet, so whether the block gets sent or not is undefined. We take the easy route of only needing one conditional.
-								if hash, ok := ctx.hashes[pos]; ctx.hashes != nil && (!ok || hash != block.Hash) {
-									resp1.MapBlocks = append(resp1.MapBlocks, block.Block)
-									ctx.hashes[pos] = block.Hash
-									blocks_sent++
-								}
-							}
-						}
-
-						// make a step, add 'direction' vector (di, dj) to current position (i, j)
-						i += di
-						j += dj
-						segment_passed++
-
-						if segment_passed == segment_length {
-							// done with current segment
-							segment_passed = 0
-
-							// 'rotate' directions
-							di, dj = -dj, di
-
-							// increase segment length if necessary
-							if dj == 0 {
-								segment_length++
-							}
-						}
-					}
-				}
-
-				return ctx.WriteMessage(resp1)
-			},
+	CoreMessages   = make(map[string]int16)
+	PluginMessages = make(map[string]map[string]int16)
+
+	// AllowedMessages is built by ApplyPolicy. A nil Rule means the
+	// method is denied.
+	AllowedMessages []struct {
+		dfhack.Method
+		Rule   *PolicyRule
+		Handle func(*proxy_ctx) error
+	}
+
+	// ProxyHandlers replace the default handler, which forwards the call
+	// to DFHack, for methods the proxy answers itself.
+	ProxyHandlers = map[[2]string]func(*proxy_ctx) error{
+		{"", "BindMethod"}: nil, // assigned below
+		{"", "RunCommand"}: func(ctx *proxy_ctx) error {
+			var req dfproto.CoreRunCommandRequest
+			if err := ctx.ReadMessage(&req); err != nil {
+				return err
+			}
+
+			if !AllowedMessages[1].Rule.AllowsCommand(req.GetCommand()) {
+				return ctx.WriteError(cr_wrong_usage, fmt.Sprintf("Command not allowed: %s\n", req.GetCommand()))
+			}
+
+			var text []*dfproto.CoreTextNotification
+			err := ctx.Upstream(func(c context.Context, remote *dfhack.Conn) (err error) {
+				text, err = remote.RunCommandContext(c, &req)
+				return
+			})
+			return ctx.Respond(&dfproto.EmptyMessage{}, text, err)
 		},
-		{
-			Command: "GetPlantList",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "RemoteFortressReader.BlockRequest",
-			Out:     "RemoteFortressReader.PlantList",
-			Handle: func(ctx *proxy_ctx) error {
-				var req RemoteFortressReader.BlockRequest
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetPlantList(&req)
-				return ctx.Respond(resp, text, err)
-			},
+		{"", "GetVersion"}: func(ctx *proxy_ctx) error {
+			var req dfproto.EmptyMessage
+			if err := ctx.ReadMessage(&req); err != nil {
+				return err
+			}
+
+			version, _, err := ctx.session.Fortress.versions(ctx.session.Context)
+			return ctx.Respond(version, nil, err)
 		},
-		{
-			Command: "GetUnitList",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "dfproto.EmptyMessage",
-			Out:     "RemoteFortressReader.UnitList",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetUnitList()
-				return ctx.Respond(resp, text, err)
-			},
+		{"", "GetDFVersion"}: func(ctx *proxy_ctx) error {
+			var req dfproto.EmptyMessage
+			if err := ctx.ReadMessage(&req); err != nil {
+				return err
+			}
+
+			_, dfVersion, err := ctx.session.Fortress.versions(ctx.session.Context)
+			return ctx.Respond(dfVersion, nil, err)
 		},
-		{
-			Command: "GetViewInfo",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "dfproto.EmptyMessage",
-			Out:     "RemoteFortressReader.ViewInfo",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetViewInfo()
-				return ctx.Respond(resp, text, err)
-			},
+		{pluginRemoteFortressReader, "ResetMapHashes"}: func(ctx *proxy_ctx) error {
+			var req dfproto.EmptyMessage
+			if err := ctx.ReadMessage(&req); err != nil {
+				return err
+			}
+
+			ctx.session.hashMtx.Lock()
+			ctx.session.Hashes = make(mapcache.Hashes)
+			ctx.session.hashMtx.Unlock()
+
+			return ctx.WriteMessage(&dfproto.EmptyMessage{})
 		},
-		{
-			Command: "GetMapInfo",
-			Plugin:  &pluginRemoteFortressReader,
-			In:      "dfproto.EmptyMessage",
-			Out:     "RemoteFortressReader.MapInfo",
-			Handle: func(ctx *proxy_ctx) error {
-				var req dfproto.EmptyMessage
-				if err := ctx.ReadMessage(&req); err != nil {
-					return err
-				}
-
-				resp, text, err := Remote.GetMapInfo()
-				return ctx.Respond(resp, text, err)
-			},
+		{pluginRemoteFortressReader, "GetBlockList"}: func(ctx *proxy_ctx) error {
+			var req RemoteFortressReader.BlockRequest
+			if err := ctx.ReadMessage(&req); err != nil {
+				return err
+			}
+
+			limit := req.BlocksNeeded
+			req.BlocksNeeded = nil
+			mapX, mapY, text, err := fetchBlocks(ctx, &req)
+			if ok, err1 := ctx.RespondPartial(text, err); !ok {
+				return err1
+			}
+			req.BlocksNeeded = limit
+
+			ctx.session.hashMtx.Lock()
+			resp, skipped := ctx.session.Fortress.MapCache.Delta(&req, ctx.session.Hashes)
+			ctx.session.hashMtx.Unlock()
+			BlocksSent.Add(int64(len(resp.MapBlocks)))
+			BlocksSkipped.Add(int64(skipped))
+			resp.MapX = mapX
+			resp.MapY = mapY
+
+			return ctx.WriteMessage(resp)
 		},
 	}
 )
 
 func init() {
-	AllowedMessages[0].Handle = func(ctx *proxy_ctx) error {
+	ProxyHandlers[[2]string{"", "BindMethod"}] = func(ctx *proxy_ctx) error {
 		var req dfproto.CoreBindRequest
 		if err := ctx.ReadMessage(&req); err != nil {
 			return err
@@ -545,7 +297,7 @@ func init() {
 			id, ok = PluginMessages[req.GetPlugin()][req.GetMethod()]
 		}
 
-		if !ok {
+		if !ok || AllowedMessages[id].Rule == nil {
 			return ctx.WriteError(cr_failure, fmt.Sprintf("RPC method not found: %s::%s\n", req.GetPlugin(), req.GetMethod()))
 		}
 
@@ -555,52 +307,32 @@ func init() {
 			return ctx.WriteError(cr_failure, fmt.Sprintf("Requested wrong signature for RPC method: %s::%s (%q -> %q, %q -> %q)\n", req.GetPlugin(), req.GetMethod(), req.GetInputMsg(), msg.In, req.GetOutputMsg(), msg.Out))
 		}
 
+		if msg.Rule.role > ctx.session.Role {
+			return ctx.WriteError(cr_failure, fmt.Sprintf("RPC method requires the %v role: %s::%s\n", msg.Rule.role, req.GetPlugin(), req.GetMethod()))
+		}
+
 		return ctx.WriteMessage(&dfproto.CoreBindReply{
 			AssignedId: proto.Int32(int32(id)),
 		})
 	}
-
-	for i, msg := range AllowedMessages {
-		if msg.Plugin == nil {
-			CoreMessages[msg.Command] = int16(i)
-		} else {
-			m, ok := PluginMessages[*msg.Plugin]
-			if !ok {
-				m = make(map[string]int16)
-				PluginMessages[*msg.Plugin] = m
-			}
-			m[msg.Command] = int16(i)
-		}
-	}
 }
 
-var remoteOnce sync.Once
-var Remote *dfhack.Conn
-var RemoteVersion *dfproto.StringMessage
-var RemoteDFVersion *dfproto.StringMessage
-
-func remote() {
-	var err error
-	Remote, err = dfhack.Connect()
-	if err != nil {
-		log.Panicln("remote connect:", err)
-	}
-
-	version, _, err := Remote.GetVersion()
-	if err != nil {
-		log.Panicln("remote GetVersion:", err)
-	}
-	RemoteVersion = &dfproto.StringMessage{Value: proto.String(version)}
-
-	version, _, err = Remote.GetDFVersion()
-	if err != nil {
-		log.Panicln("remote GetDFVersion:", err)
-	}
-	RemoteDFVersion = &dfproto.StringMessage{Value: proto.String(version)}
+// forwardMethod returns a handler that passes the call through to the
+// fortress's DFHack unchanged.
+func forwardMethod(m dfhack.Method) func(*proxy_ctx) error {
+	return func(ctx *proxy_ctx) error {
+		req := m.NewIn()
+		if err := ctx.ReadMessage(req); err != nil {
+			return err
+		}
 
-	_, err = Remote.ResetMapHashes()
-	if err != nil {
-		log.Panicln("remote ResetMapHashes:", err)
+		resp := m.NewOut()
+		var text []*dfproto.CoreTextNotification
+		err := ctx.Upstream(func(c context.Context, remote *dfhack.Conn) (err error) {
+			text, err = remote.Call(c, m, req, resp)
+			return
+		})
+		return ctx.Respond(resp, text, err)
 	}
 }
 
@@ -629,20 +361,82 @@ func proxy(in *websocket.Conn) {
 		return
 	}
 
-	log.Println(addr, "connect")
+	f := requestFortress(in.Request())
+	session, err := Sessions.Open(context.Background(), addr, requestRole(in.Request()), f)
+	if err != nil {
+		log.Println(addr, err)
+		return
+	}
+	defer Sessions.Close(session)
+	session.Attach(in)
+
+	if f.Name != "" {
+		log.Println(addr, "connect: session", session.ID, "to", f.Name, "as", session.Role)
+	} else {
+		log.Println(addr, "connect: session", session.ID, "as", session.Role)
+	}
 
-	remoteOnce.Do(remote)
+	f.Connect()
 
-	var buf bytes.Buffer
+	// Each reply is collected in a buffer and sent in one piece, so that
+	// pushes sent while the call is in progress don't interleave with it.
+	var buf, reply bytes.Buffer
 	ctx := &proxy_ctx{
-		w: in,
+		w:       &reply,
+		session: session,
+	}
+
+	// A client that asks for it gets its replies as one deflate stream,
+	// flushed after each reply. Requests are small, so they are never
+	// compressed.
+	var w io.Writer = in
+	var flush func() error
+	if in.Request().URL.Query().Get("compress") == "deflate" {
+		compressed, _ := flate.NewWriter(in, flate.DefaultCompression)
+		w = compressed
+		flush = compressed.Flush
+	}
+
+	pushes := newPusher(session, w, flush)
+	if PushInterval > 0 {
+		// The session stays open until the pusher has stopped, so Drain
+		// waits for its calls too.
+		pushed := make(chan struct{})
+		go func() {
+			defer close(pushed)
+			pushes.run()
+		}()
+		defer func() {
+			session.cancel()
+			<-pushed
+		}()
 	}
 
 	for {
+		subscribed := pushes.subscribed()
+
+		// A subscribed client is expected to sit and wait for pushes.
+		// If it is gone, writing the next one will fail.
+		if !session.BeforeRead(func() {
+			if Sessions.IdleTimeout != 0 && !subscribed {
+				in.SetReadDeadline(time.Now().Add(Sessions.IdleTimeout))
+			} else {
+				in.SetReadDeadline(time.Time{})
+			}
+		}) {
+			log.Println(addr, "disconnect: shutting down")
+			return
+		}
+
 		var header rpcMessageHeader
 		err = binary.Read(in, binary.LittleEndian, &header)
 		if err != nil {
-			log.Println(addr, "reading header:", err)
+			if Sessions.Draining() {
+				// Drain interrupted the read.
+				log.Println(addr, "disconnect: shutting down")
+			} else {
+				log.Println(addr, "reading header:", err)
+			}
 			return
 		}
 		if header.ID == rpcRequestQuit {
@@ -661,11 +455,25 @@ func proxy(in *websocket.Conn) {
 			return
 		}
 		ctx.b = buf.Bytes()
+		ctx.ack, ctx.acked = false, nil
+		reply.Reset()
 
 		if header.ID < 0 || header.ID >= int16(len(AllowedMessages)) {
 			err = ctx.WriteError(cr_not_found, fmt.Sprintf("RPC call of invalid id %d\n", header.ID))
+		} else if msg := AllowedMessages[header.ID]; msg.Rule == nil {
+			// only RunCommand can be denied but still have an id.
+			err = ctx.WriteError(cr_not_implemented, "")
+		} else if msg.Rule.role > session.Role {
+			err = ctx.WriteError(cr_failure, fmt.Sprintf("RPC method requires the %v role: %s::%s\n", msg.Rule.role, msg.Plugin, msg.Name))
+		} else if ok, err1 := ctx.Limit(msg.Rule, msg.Plugin+"::"+msg.Name); !ok {
+			err = err1
 		} else {
-			err = AllowedMessages[header.ID].Handle(ctx)
+			ctx.ack = pushes.accept(header.ID, header.Pad)
+			err = msg.Handle(ctx)
+			pushes.update(header.ID, header.Pad, ctx)
+		}
+		if err == nil {
+			err = pushes.send(reply.Bytes())
 		}
 		if err != nil {
 			log.Println(addr, "writing response:", err)
@@ -674,6 +482,27 @@ func proxy(in *websocket.Conn) {
 	}
 }
 
+// Sessions is configured from flags in main.
+var Sessions *SessionManager
+
 func init() {
-	http.Handle("/ws", websocket.Handler(proxy))
+	ws := websocket.Handler(proxy)
+	mux.Handle("/ws", requireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestFortress(r) == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		// Turn clients away before upgrading, so they get a useful
+		// status. Open checks again in case of a race.
+		if Sessions.Draining() {
+			http.Error(w, ErrShuttingDown.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if Sessions.Full() {
+			http.Error(w, ErrTooManySessions.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		ws.ServeHTTP(w, r)
+	})))
 }
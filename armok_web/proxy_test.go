@@ -0,0 +1,419 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/websocket"
+)
+
+// A testProxy is armok_web serving a fake DFHack.
+type testProxy struct {
+	t      *testing.T
+	DFHack *dfhacktest.Server
+	HTTP   *httptest.Server
+
+	mtx    sync.Mutex
+	blocks []*RemoteFortressReader.MapBlock
+}
+
+// newTestProxy starts armok_web with the default policy, a viewer token
+// "view", and a controller token "control".
+func newTestProxy(t *testing.T) *testProxy {
+	p := &testProxy{t: t, DFHack: dfhacktest.NewServer()}
+
+	p.DFHack.Reply("", "GetVersion", &dfproto.StringMessage{Value: proto.String("0.47.05-r1")})
+	p.DFHack.Reply("", "GetDFVersion", &dfproto.StringMessage{Value: proto.String("0.47.05")})
+	p.DFHack.Reply("RemoteFortressReader", "ResetMapHashes", nil)
+	p.DFHack.Reply("RemoteFortressReader", "SetPauseState", nil)
+	p.DFHack.Reply("RemoteFortressReader", "GetViewInfo", &RemoteFortressReader.ViewInfo{ViewPosX: proto.Int32(1)})
+	p.DFHack.Handle("RemoteFortressReader", "GetBlockList", func(proto.Message) dfhacktest.Reply {
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+
+		return dfhacktest.Reply{Result: &RemoteFortressReader.BlockList{
+			MapX:      proto.Int32(0),
+			MapY:      proto.Int32(0),
+			MapBlocks: p.blocks,
+		}}
+	})
+	p.DFHack.Handle("", "RunCommand", func(req proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{Text: []*dfproto.CoreTextNotification{
+			dfhacktest.Text(req.(*dfproto.CoreRunCommandRequest).GetCommand()),
+		}}
+	})
+
+	addr, err := p.DFHack.Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Fortresses = []*Fortress{NewFortress("", addr, 4, 32)}
+	Sessions = NewSessionManager()
+	ApplyPolicy(DefaultPolicy)
+	Authenticators = []Authenticator{
+		&TokenAuth{Token: "view", Role: RoleViewer},
+		&TokenAuth{Token: "control", Role: RoleController},
+	}
+	PushInterval = 10 * time.Millisecond
+
+	p.HTTP = httptest.NewServer(rootHandler())
+
+	return p
+}
+
+// SetBlocks sets the blocks DFHack has.
+func (p *testProxy) SetBlocks(blocks ...*RemoteFortressReader.MapBlock) {
+	p.mtx.Lock()
+	p.blocks = blocks
+	p.mtx.Unlock()
+}
+
+// Dial connects to the proxy with token.
+func (p *testProxy) Dial(token string) (*dfhack.Conn, error) {
+	url := "ws" + strings.TrimPrefix(p.HTTP.URL, "http") + "/ws?token=" + token
+
+	return (&dfhack.Dialer{
+		DialSocket: func(ctx context.Context, addr string) (io.ReadWriteCloser, error) {
+			return websocket.Dial(url, "", p.HTTP.URL)
+		},
+	}).Dial("armok_web")
+}
+
+// Close waits for the clients, which must have disconnected, and stops
+// the proxy and DFHack.
+func (p *testProxy) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := Sessions.Drain(ctx); err != nil {
+		p.t.Error("draining sessions:", err)
+	}
+	p.HTTP.Close()
+	for _, f := range Fortresses {
+		f.Close()
+	}
+	_ = p.DFHack.Close()
+
+	Authenticators = nil
+}
+
+func testBlock(x, y, z int32, tile int32) *RemoteFortressReader.MapBlock {
+	tiles := make([]int32, 256)
+	for i := range tiles {
+		tiles[i] = tile
+	}
+
+	return &RemoteFortressReader.MapBlock{
+		MapX:  proto.Int32(x * 16),
+		MapY:  proto.Int32(y * 16),
+		MapZ:  proto.Int32(z),
+		Tiles: tiles,
+	}
+}
+
+func TestProxyRoles(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	if _, err := p.Dial("wrong"); err == nil {
+		t.Error("connected with a wrong token")
+	}
+
+	viewer, err := p.Dial("view")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer viewer.Close()
+
+	controller, err := p.Dial("control")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer controller.Close()
+
+	for _, c := range []*dfhack.Conn{viewer, controller} {
+		if v, _, err := c.GetVersion(); err != nil || v != "0.47.05-r1" {
+			t.Errorf("GetVersion: got %q, %v", v, err)
+		}
+		if info, _, err := c.GetViewInfo(); err != nil || info.GetViewPosX() != 1 {
+			t.Errorf("GetViewInfo: got %v, %v", info, err)
+		}
+	}
+
+	pause := &RemoteFortressReader.SingleBool{Value: proto.Bool(true)}
+	if _, err := viewer.SetPauseState(pause); !errors.Is(err, dfhack.ErrFailure) {
+		t.Errorf("viewer SetPauseState: got %v, want %v", err, dfhack.ErrFailure)
+	} else if !strings.Contains(err.Error(), "requires the controller role") {
+		t.Errorf("viewer SetPauseState: %v", err)
+	}
+	if _, err := controller.SetPauseState(pause); err != nil {
+		t.Errorf("controller SetPauseState: %v", err)
+	}
+
+	cmd := &dfproto.CoreRunCommandRequest{Command: proto.String("die")}
+	if _, err := viewer.RunCommand(cmd); err == nil {
+		t.Error("viewer RunCommand succeeded")
+	}
+	if text, err := controller.RunCommand(cmd); err != nil || len(text) != 1 {
+		t.Errorf("controller RunCommand: got %v, %v", text, err)
+	}
+}
+
+func TestProxyBlockDeltas(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	p.SetBlocks(testBlock(1, 1, 1, 10), testBlock(2, 1, 1, 10))
+
+	c1, err := p.Dial("view")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c1.Close()
+
+	c2, err := p.Dial("view")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c2.Close()
+
+	req := &RemoteFortressReader.BlockRequest{
+		MinX: proto.Int32(0), MaxX: proto.Int32(4),
+		MinY: proto.Int32(0), MaxY: proto.Int32(4),
+		MinZ: proto.Int32(0), MaxZ: proto.Int32(3),
+	}
+	blocks := func(c *dfhack.Conn) int {
+		list, _, err := c.GetBlockList(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(list.MapBlocks)
+	}
+
+	if n := blocks(c1); n != 0 {
+		t.Errorf("before ResetMapHashes: got %d blocks, want none", n)
+	}
+
+	for _, c := range []*dfhack.Conn{c1, c2} {
+		if _, err := c.ResetMapHashes(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := blocks(c1); n != 2 {
+		t.Errorf("first request: got %d blocks, want 2", n)
+	}
+	if n := blocks(c1); n != 0 {
+		t.Errorf("nothing changed: got %d blocks, want none", n)
+	}
+
+	p.SetBlocks(testBlock(1, 1, 1, 10), testBlock(2, 1, 1, 20))
+	if n := blocks(c1); n != 1 {
+		t.Errorf("one block changed: got %d blocks, want 1", n)
+	}
+
+	// Each client has its own hashes.
+	if n := blocks(c2); n != 2 {
+		t.Errorf("second client: got %d blocks, want 2", n)
+	}
+
+	if _, err := c1.ResetMapHashes(); err != nil {
+		t.Fatal(err)
+	}
+	if n := blocks(c1); n != 2 {
+		t.Errorf("after ResetMapHashes: got %d blocks, want 2", n)
+	}
+}
+
+func TestProxyPush(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	c, err := p.Dial("view")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	m, _ := dfhack.LookupMethod("RemoteFortressReader", "GetViewInfo")
+	pushed := make(chan *RemoteFortressReader.ViewInfo, 10)
+	var info RemoteFortressReader.ViewInfo
+	if _, err := c.Subscribe(context.Background(), m, &dfproto.EmptyMessage{}, &info, func(msg proto.Message) {
+		pushed <- msg.(*RemoteFortressReader.ViewInfo)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if info.GetViewPosX() != 1 {
+		t.Errorf("subscribe: got %v", &info)
+	}
+
+	select {
+	case msg := <-pushed:
+		t.Errorf("pushed %v without a change", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	p.DFHack.Reply("RemoteFortressReader", "GetViewInfo", &RemoteFortressReader.ViewInfo{ViewPosX: proto.Int32(2)})
+	select {
+	case msg := <-pushed:
+		if msg.GetViewPosX() != 2 {
+			t.Errorf("pushed %v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no push after a change")
+	}
+
+	if _, err := c.Unsubscribe(context.Background(), m, &dfproto.EmptyMessage{}, &info); err != nil {
+		t.Fatal(err)
+	}
+
+	p.DFHack.Reply("RemoteFortressReader", "GetViewInfo", &RemoteFortressReader.ViewInfo{ViewPosX: proto.Int32(3)})
+	select {
+	case msg := <-pushed:
+		t.Errorf("pushed %v after unsubscribing", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// DFHack itself answers, but doesn't push.
+	direct, err := p.DFHack.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer direct.Close()
+	if _, err := direct.Subscribe(context.Background(), m, &dfproto.EmptyMessage{}, &info, func(proto.Message) {}); !errors.Is(err, dfhack.ErrNoSubscriptions) {
+		t.Errorf("subscribe to DFHack: got %v, want %v", err, dfhack.ErrNoSubscriptions)
+	}
+}
+
+func TestProxyPushSlow(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	c, err := p.Dial("view")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	m, _ := dfhack.LookupMethod("RemoteFortressReader", "GetViewInfo")
+	var info RemoteFortressReader.ViewInfo
+	if _, err := c.Subscribe(context.Background(), m, &dfproto.EmptyMessage{}, &info, func(proto.Message) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	// While a push is being checked, the client's other calls are still
+	// answered.
+	const delay = time.Second
+	p.DFHack.Handle("RemoteFortressReader", "GetViewInfo", func(proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{Result: &RemoteFortressReader.ViewInfo{ViewPosX: proto.Int32(2)}, Delay: delay}
+	})
+	time.Sleep(5 * PushInterval)
+
+	start := time.Now()
+	if _, _, err := c.GetDFVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed >= delay/2 {
+		t.Errorf("GetDFVersion took %v while a push was slow", elapsed)
+	}
+}
+
+func TestProxyPushBlocked(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	c, err := p.Dial("view")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// A handler that is still busy with a push doesn't hold up replies.
+	m, _ := dfhack.LookupMethod("RemoteFortressReader", "GetViewInfo")
+	pushed := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	var info RemoteFortressReader.ViewInfo
+	if _, err := c.Subscribe(context.Background(), m, &dfproto.EmptyMessage{}, &info, func(proto.Message) {
+		select {
+		case pushed <- struct{}{}:
+		default:
+		}
+		<-release
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	p.DFHack.Reply("RemoteFortressReader", "GetViewInfo", &RemoteFortressReader.ViewInfo{ViewPosX: proto.Int32(2)})
+	select {
+	case <-pushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no push after a change")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, _, err := c.GetViewInfoContext(ctx); err != nil {
+		t.Error("calling while a push handler is blocked:", err)
+	}
+}
+
+func TestProxyReconnect(t *testing.T) {
+	p := newTestProxy(t)
+	defer p.Close()
+
+	c, err := p.Dial("view")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, _, err := c.GetViewInfo(); err != nil {
+		t.Fatal(err)
+	}
+
+	// While DFHack can't be reconnected to, calls fail with the reason.
+	p.DFHack.Fail("", "GetVersion", cr_failure, "")
+	p.DFHack.Disconnect()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, _, err := c.GetViewInfo()
+		var rpcErr *dfhack.RPCError
+		if !errors.As(err, &rpcErr) || rpcErr.Code != cr_link_failure {
+			t.Fatalf("calling while disconnected: got %v, want CR_LINK_FAILURE", err)
+		}
+		if strings.HasPrefix(rpcErr.Text, "DFHack is not reachable (") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("still %q after the reconnect failed", rpcErr.Text)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	p.DFHack.Reply("", "GetVersion", &dfproto.StringMessage{Value: proto.String("0.47.05-r1")})
+	deadline = time.Now().Add(5 * time.Second)
+	for {
+		_, _, err := c.GetViewInfo()
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("not reconnected:", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// rpcReplyPush carries a new reply to a subscribed method, whose id is in
+// the padding. Clients subscribe by setting the first byte of a request's
+// padding to padSubscribe; the result is acknowledged the same way. DFHack
+// ignores the padding, so clients can tell whether they are talking to the
+// proxy.
+const rpcReplyPush int16 = -5
+
+const (
+	padSubscribe   byte = 1
+	padUnsubscribe byte = 2
+)
+
+// PushTopics are the methods clients may subscribe to, as {plugin, method}.
+// The value reports whether a reply can be shared between clients making
+// the same request; GetBlockList replies depend on what the client already
+// has.
+var PushTopics = map[[2]string]bool{
+	{pluginRemoteFortressReader, "GetViewInfo"}:       true,
+	{pluginRemoteFortressReader, "GetUnitList"}:       true,
+	{pluginRemoteFortressReader, "GetUnitListInside"}: true,
+	{pluginRemoteFortressReader, "GetPlantList"}:      true,
+	{pluginRemoteFortressReader, "GetMapInfo"}:        true,
+	{pluginRemoteFortressReader, "GetPauseState"}:     true,
+	{pluginRemoteFortressReader, "GetReports"}:        true,
+	{pluginRemoteFortressReader, "GetBlockList"}:      false,
+}
+
+// PushInterval is how often subscriptions are checked for changes. Zero
+// disables subscriptions.
+var PushInterval time.Duration
+
+// A pusher sends a client new replies to the methods it subscribed to.
+// Subscriptions are checked as if the client had called them again, but
+// they skip the rate limits; the role is checked when subscribing.
+type pusher struct {
+	session *Session
+	w       io.Writer
+	flush   func() error
+
+	// mtx guards subs. It is never held during a call or a write, so a
+	// slow call doesn't hold up the client's other replies.
+	mtx  sync.Mutex
+	subs map[int16]*pushSub
+
+	// writeMtx is held while a reply or a push is written, so that they
+	// never interleave.
+	writeMtx sync.Mutex
+}
+
+type pushSub struct {
+	req    []byte
+	result []byte // the last one the client was sent
+}
+
+func newPusher(session *Session, w io.Writer, flush func() error) *pusher {
+	return &pusher{
+		session: session,
+		w:       w,
+		flush:   flush,
+		subs:    make(map[int16]*pushSub),
+	}
+}
+
+// accept reports whether a request with pad subscribes to id.
+func (p *pusher) accept(id int16, pad [2]byte) bool {
+	if pad[0] != padSubscribe || PushInterval <= 0 {
+		return false
+	}
+	_, ok := PushTopics[[2]string{AllowedMessages[id].Plugin, AllowedMessages[id].Name}]
+	return ok
+}
+
+// update records the outcome of a call that may have subscribed to or
+// unsubscribed from id.
+func (p *pusher) update(id int16, pad [2]byte, ctx *proxy_ctx) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	switch {
+	case ctx.acked != nil:
+		p.subs[id] = &pushSub{
+			req:    append([]byte(nil), ctx.b...),
+			result: ctx.acked,
+		}
+	case pad[0] == padUnsubscribe:
+		delete(p.subs, id)
+	}
+}
+
+// run checks the subscriptions every PushInterval until the client
+// disconnects.
+func (p *pusher) run() {
+	t := time.NewTicker(PushInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-p.session.Context.Done():
+			return
+		}
+
+		if err := p.push(); err != nil {
+			log.Println(p.session.Addr, "writing push:", err)
+			return
+		}
+	}
+}
+
+// subscribed reports whether the client has subscribed to anything.
+func (p *pusher) subscribed() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return len(p.subs) != 0
+}
+
+func (p *pusher) push() error {
+	// The subscriptions may change while they are being checked, so
+	// they are copied first.
+	p.mtx.Lock()
+	type check struct {
+		id     int16
+		sub    *pushSub
+		req    []byte
+		result []byte
+	}
+	checks := make([]check, 0, len(p.subs))
+	for id, sub := range p.subs {
+		checks = append(checks, check{id, sub, sub.req, sub.result})
+	}
+	p.mtx.Unlock()
+
+	for _, c := range checks {
+		msg := AllowedMessages[c.id]
+
+		var result []byte
+		var ok bool
+		if PushTopics[[2]string{msg.Plugin, msg.Name}] {
+			result, ok = p.session.Fortress.pushes.get(c.id, c.req, func() ([]byte, bool) {
+				return p.call(c.id, c.req)
+			})
+		} else {
+			result, ok = p.call(c.id, c.req)
+		}
+		if !ok || bytes.Equal(result, c.result) {
+			continue
+		}
+
+		// Don't push a reply to a request the client has since
+		// replaced or unsubscribed from.
+		p.mtx.Lock()
+		current := p.subs[c.id] == c.sub
+		if current {
+			c.sub.result = result
+		}
+		p.mtx.Unlock()
+		if !current {
+			continue
+		}
+
+		if err := p.write(c.id, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// call makes a subscribed call again, returning its result if it
+// succeeded.
+func (p *pusher) call(id int16, req []byte) ([]byte, bool) {
+	var buf bytes.Buffer
+	ctx := &proxy_ctx{
+		b:       req,
+		w:       &buf,
+		session: p.session,
+	}
+
+	if err := AllowedMessages[id].Handle(ctx); err != nil {
+		return nil, false
+	}
+
+	_, result, code, err := parseReply(buf.Bytes())
+	return result, err == nil && code == 0
+}
+
+func (p *pusher) write(id int16, result []byte) error {
+	header := rpcMessageHeader{
+		ID:   rpcReplyPush,
+		Size: int32(len(result)),
+	}
+	binary.LittleEndian.PutUint16(header.Pad[:], uint16(id))
+
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, &header)
+	buf.Write(result)
+	return p.send(buf.Bytes())
+}
+
+// send writes b, which holds whole messages, to the client.
+func (p *pusher) send(b []byte) error {
+	p.writeMtx.Lock()
+	defer p.writeMtx.Unlock()
+
+	n, err := p.w.Write(b)
+	if err == nil && n != len(b) {
+		err = io.ErrShortWrite
+	}
+	if err == nil && p.flush != nil {
+		err = p.flush()
+	}
+	return err
+}
+
+// A pushCache lets a fortress's clients subscribed to the same request
+// share one call per PushInterval.
+type pushCache struct {
+	mtx     sync.Mutex
+	entries map[pushKey]*pushEntry
+}
+
+type pushKey struct {
+	id  int16
+	req string
+}
+
+type pushEntry struct {
+	used time.Time // guarded by the pushCache
+
+	mtx     sync.Mutex
+	fetched time.Time
+	result  []byte
+	ok      bool
+}
+
+func (c *pushCache) get(id int16, req []byte, fetch func() ([]byte, bool)) ([]byte, bool) {
+	c.mtx.Lock()
+	now := time.Now()
+	for k, e := range c.entries {
+		// Forget requests nobody has made in a while.
+		if now.Sub(e.used) > 10*PushInterval {
+			delete(c.entries, k)
+		}
+	}
+	k := pushKey{id: id, req: string(req)}
+	e, ok := c.entries[k]
+	if !ok {
+		e = &pushEntry{}
+		c.entries[k] = e
+	}
+	e.used = now
+	c.mtx.Unlock()
+
+	// Clients checking at the same time wait for one call instead of
+	// each making their own.
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	if time.Since(e.fetched) >= PushInterval {
+		e.result, e.ok = fetch()
+		e.fetched = time.Now()
+	}
+
+	return e.result, e.ok
+}
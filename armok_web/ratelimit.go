@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// RateLimitDelayed and RateLimitRejected count the calls held back by
+	// a limit in the policy, by method.
+	RateLimitDelayed  = newMap("armok_web_rate_limit_delayed")
+	RateLimitRejected = newMap("armok_web_rate_limit_rejected")
+	// UpstreamBusy counts the calls rejected because too many others were
+	// already waiting for DFHack.
+	UpstreamBusy = newInt("armok_web_upstream_busy")
+)
+
+// tokenBucket allows rate calls per second on average, and up to burst
+// calls at once.
+type tokenBucket struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds the tokens earned since the bucket was last used. If there
+// is less than a whole token, it returns how long until there will be one.
+// The bucket must be locked.
+func (b *tokenBucket) refill(now time.Time) time.Duration {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	return 0
+}
+
+// takeAll takes a token from every bucket if each of them has one.
+// Otherwise, it takes none and returns how long until they might. Buckets
+// must always be given in the same order: a client's before its
+// fortress's.
+func takeAll(buckets []*tokenBucket) time.Duration {
+	now := time.Now()
+
+	var wait time.Duration
+	for _, b := range buckets {
+		b.mtx.Lock()
+		defer b.mtx.Unlock()
+
+		if d := b.refill(now); d > wait {
+			wait = d
+		}
+	}
+	if wait != 0 {
+		return wait
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return 0
+}
+
+// waitAll takes a token from every bucket, waiting until each has one.
+func waitAll(ctx context.Context, buckets []*tokenBucket) error {
+	for {
+		d := takeAll(buckets)
+		if d == 0 {
+			return nil
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Limit applies the limits in rule to a call to name. If it returns false,
+// the call must not be made; the client has already been told why unless
+// the error is not nil.
+func (ctx *proxy_ctx) Limit(rule *PolicyRule, name string) (bool, error) {
+	if rule.Action != "limit" {
+		return true, nil
+	}
+
+	var buckets []*tokenBucket
+	for _, b := range [...]*tokenBucket{ctx.session.limiter(rule), ctx.session.Fortress.limiter(rule)} {
+		if b != nil {
+			buckets = append(buckets, b)
+		}
+	}
+
+	if takeAll(buckets) == 0 {
+		return true, nil
+	}
+
+	if !rule.Delay {
+		RateLimitRejected.Add(name, 1)
+		return false, ctx.WriteError(cr_failure, fmt.Sprintf("RPC method rate limited: %s\n", name))
+	}
+
+	RateLimitDelayed.Add(name, 1)
+	if err := waitAll(ctx.session.Context, buckets); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTakeAll(t *testing.T) {
+	tests := []struct {
+		name         string
+		client, fort float64 // tokens left
+		ok           bool
+	}{
+		{"both have tokens", 2, 2, true},
+		{"client out", 0, 2, false},
+		{"fortress out", 2, 0, false},
+		{"both out", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		// Slow enough that no tokens are added during the test.
+		client := newTokenBucket(0.001, 5)
+		fort := newTokenBucket(0.001, 5)
+		client.tokens, fort.tokens = tt.client, tt.fort
+
+		wait := takeAll([]*tokenBucket{client, fort})
+		if (wait == 0) != tt.ok {
+			t.Errorf("%s: got wait %v, want ok = %v", tt.name, wait, tt.ok)
+		}
+
+		// A call that isn't allowed takes no tokens.
+		wantClient, wantFort := tt.client, tt.fort
+		if tt.ok {
+			wantClient--
+			wantFort--
+		}
+		if client.tokens < wantClient || client.tokens > wantClient+0.01 || fort.tokens < wantFort || fort.tokens > wantFort+0.01 {
+			t.Errorf("%s: %.2f and %.2f tokens left, want %v and %v", tt.name, client.tokens, fort.tokens, wantClient, wantFort)
+		}
+	}
+}
+
+func TestTokenBucketRate(t *testing.T) {
+	b := newTokenBucket(100, 3)
+	buckets := []*tokenBucket{b}
+
+	for i := 0; i < 3; i++ {
+		if d := takeAll(buckets); d != 0 {
+			t.Fatalf("call %d of a burst of 3: wait %v", i+1, d)
+		}
+	}
+	d := takeAll(buckets)
+	if d <= 0 || d > 10*time.Millisecond {
+		t.Fatalf("after the burst: wait %v, want up to 10ms", d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := waitAll(ctx, buckets); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("waited %v for a token", elapsed)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := waitAll(ctx, buckets); err != context.Canceled {
+		t.Errorf("waiting with a cancelled context: got %v", err)
+	}
+}
+
+func TestLimiters(t *testing.T) {
+	rule := &PolicyRule{Method: "GetVersion", Action: "limit", Rate: 1, Burst: 1, ClientRate: 1, ClientBurst: 1}
+	if err := rule.check(); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewFortress("a", "", 1, 0)
+	b := NewFortress("b", "", 1, 0)
+	if a.limiter(rule) != a.limiter(rule) {
+		t.Error("a fortress has more than one bucket for a rule")
+	}
+	if a.limiter(rule) == b.limiter(rule) {
+		t.Error("fortresses share a bucket")
+	}
+
+	var s1, s2 Session
+	if s1.limiter(rule) != s1.limiter(rule) || s1.limiter(rule) == s2.limiter(rule) {
+		t.Error("clients don't have a bucket each")
+	}
+
+	clientOnly := &PolicyRule{Method: "GetVersion", Action: "limit", ClientRate: 1}
+	if err := clientOnly.check(); err != nil {
+		t.Fatal(err)
+	}
+	if a.limiter(clientOnly) != nil {
+		t.Error("fortress bucket for a rule with no Rate")
+	}
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/mapcache"
+)
+
+var (
+	ErrTooManySessions = errors.New("armok_web: too many viewers")
+	ErrUpstreamBusy    = errors.New("armok_web: too many calls waiting for DFHack")
+	ErrShuttingDown    = errors.New("armok_web: shutting down")
+)
+
+// A Session is the state of one connected client.
+type Session struct {
+	ID       uint64
+	Addr     string
+	Role     Role
+	Fortress *Fortress
+
+	// Context is cancelled when the client disconnects, abandoning any
+	// upstream call made on its behalf.
+	Context context.Context
+	cancel  context.CancelFunc
+
+	Started time.Time
+
+	// Hashes is nil until the client calls ResetMapHashes. It is guarded
+	// by hashMtx, since pushes are checked while the client's own calls
+	// are in progress.
+	Hashes  mapcache.Hashes
+	hashMtx sync.Mutex
+
+	// limiters holds the per-client limits from the policy. It is only
+	// used by one call at a time.
+	limiters map[*PolicyRule]*tokenBucket
+
+	// view is the area of the map the client last asked for. It is
+	// guarded by the SessionManager.
+	view *RemoteFortressReader.BlockRequest
+
+	// drainMtx guards conn and draining, so that a session is never left
+	// waiting for a request after being told to stop.
+	drainMtx sync.Mutex
+	conn     sessionConn
+	draining bool
+}
+
+// sessionConn is the part of a client's connection used to end its
+// session.
+type sessionConn interface {
+	SetReadDeadline(time.Time) error
+	Close() error
+}
+
+// Attach sets the connection to interrupt when the session is drained.
+func (s *Session) Attach(conn sessionConn) {
+	s.drainMtx.Lock()
+	defer s.drainMtx.Unlock()
+
+	s.conn = conn
+}
+
+// BeforeRead calls arm, which sets the deadline for reading the client's
+// next request, unless the session is being drained. It reports whether
+// the session should carry on.
+func (s *Session) BeforeRead(arm func()) bool {
+	s.drainMtx.Lock()
+	defer s.drainMtx.Unlock()
+
+	if s.draining {
+		return false
+	}
+	arm()
+	return true
+}
+
+// drain ends the session once its current call, if any, is done.
+func (s *Session) drain() {
+	s.drainMtx.Lock()
+	defer s.drainMtx.Unlock()
+
+	s.draining = true
+	if s.conn != nil {
+		s.conn.SetReadDeadline(time.Now())
+	}
+}
+
+// kill ends the session now, abandoning its calls.
+func (s *Session) kill() {
+	s.cancel()
+
+	s.drainMtx.Lock()
+	defer s.drainMtx.Unlock()
+
+	s.draining = true
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+func (s *Session) limiter(rule *PolicyRule) *tokenBucket {
+	if rule.ClientRate == 0 {
+		return nil
+	}
+
+	b, ok := s.limiters[rule]
+	if !ok {
+		if s.limiters == nil {
+			s.limiters = make(map[*PolicyRule]*tokenBucket)
+		}
+		b = newTokenBucket(rule.ClientRate, rule.ClientBurst)
+		s.limiters[rule] = b
+	}
+	return b
+}
+
+// A SessionManager tracks connected clients and schedules their calls to
+// DFHack.
+type SessionManager struct {
+	// MaxSessions limits the number of clients connected at once. Zero
+	// means no limit.
+	MaxSessions int
+
+	// IdleTimeout disconnects a client that hasn't sent a request for
+	// this long. Zero means never.
+	IdleTimeout time.Duration
+
+	mtx      sync.Mutex
+	sessions map[uint64]*Session
+	nextID   uint64
+
+	// drained is closed when the last session ends after Drain.
+	draining bool
+	drained  chan struct{}
+}
+
+func NewSessionManager() *SessionManager {
+	return &SessionManager{
+		sessions: make(map[uint64]*Session),
+	}
+}
+
+// Full reports whether another client would be turned away.
+func (m *SessionManager) Full() bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.draining || (m.MaxSessions > 0 && len(m.sessions) >= m.MaxSessions)
+}
+
+// Draining reports whether Drain has been called.
+func (m *SessionManager) Draining() bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.draining
+}
+
+// Drain turns away new clients and ends every session once its current
+// call is done. It waits for them to end until ctx is done, then ends the
+// rest immediately.
+func (m *SessionManager) Drain(ctx context.Context) error {
+	m.mtx.Lock()
+	m.draining = true
+	drained := make(chan struct{})
+	if len(m.sessions) == 0 {
+		close(drained)
+	} else {
+		m.drained = drained
+	}
+	for _, s := range m.sessions {
+		s.drain()
+	}
+	m.mtx.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+	}
+
+	m.mtx.Lock()
+	for _, s := range m.sessions {
+		s.kill()
+	}
+	m.mtx.Unlock()
+
+	return ctx.Err()
+}
+
+// Open starts a session for a client at addr with role, viewing f. Its
+// context is cancelled when ctx is. The session must be closed when the
+// client disconnects.
+func (m *SessionManager) Open(ctx context.Context, addr string, role Role, f *Fortress) (*Session, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.draining {
+		return nil, ErrShuttingDown
+	}
+	if m.MaxSessions > 0 && len(m.sessions) >= m.MaxSessions {
+		return nil, ErrTooManySessions
+	}
+
+	m.nextID++
+	s := &Session{
+		ID:       m.nextID,
+		Addr:     addr,
+		Role:     role,
+		Fortress: f,
+		Started:  time.Now(),
+	}
+	s.Context, s.cancel = context.WithCancel(ctx)
+	m.sessions[s.ID] = s
+
+	return s, nil
+}
+
+// Close ends a session and cancels its upstream calls.
+func (m *SessionManager) Close(s *Session) {
+	s.cancel()
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.sessions, s.ID)
+	if len(m.sessions) == 0 && m.drained != nil {
+		close(m.drained)
+		m.drained = nil
+	}
+}
+
+// Len returns the number of open sessions.
+func (m *SessionManager) Len() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.sessions)
+}
+
+// SetView records the area of the map s is looking at.
+func (m *SessionManager) SetView(s *Session, req *RemoteFortressReader.BlockRequest) {
+	view := &RemoteFortressReader.BlockRequest{
+		MinX: req.MinX,
+		MaxX: req.MaxX,
+		MinY: req.MinY,
+		MaxY: req.MaxY,
+		MinZ: req.MinZ,
+		MaxZ: req.MaxZ,
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s.view = view
+}
+
+// Views returns the areas of f's map every session is looking at.
+func (m *SessionManager) Views(f *Fortress) []*RemoteFortressReader.BlockRequest {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var views []*RemoteFortressReader.BlockRequest
+	for _, s := range m.sessions {
+		if s.view != nil && s.Fortress == f {
+			views = append(views, s.view)
+		}
+	}
+	return views
+}
+
+// Upstream runs fn, which calls the session's DFHack, once the session's
+// turn comes. It returns the session's context error instead if the client
+// disconnects first, ErrUpstreamBusy if too many calls are already
+// waiting, or ErrNotConnected without waiting if DFHack can't be reached.
+func (m *SessionManager) Upstream(s *Session, fn func(ctx context.Context, remote *dfhack.Conn) error) error {
+	remote, err := s.Fortress.conn(s.Context)
+	if err != nil {
+		return err
+	}
+
+	upstream := s.Fortress.upstream
+	if err := upstream.acquire(s.Context, true); err != nil {
+		return err
+	}
+	defer upstream.release()
+
+	return fn(s.Context, remote)
+}
+
+// scheduler limits the number of calls in progress, admitting waiting
+// callers in the order they arrived. Each client waits for one call at a
+// time, so this takes turns between clients instead of letting a busy one
+// starve the rest.
+type scheduler struct {
+	mtx        sync.Mutex
+	limit      int
+	maxWaiting int
+	running    int
+	waiting    []chan struct{}
+}
+
+func newScheduler(limit, maxWaiting int) *scheduler {
+	if limit < 1 {
+		limit = 1
+	}
+	return &scheduler{limit: limit, maxWaiting: maxWaiting}
+}
+
+func (s *scheduler) acquire(ctx context.Context, mayReject bool) error {
+	s.mtx.Lock()
+	if s.running < s.limit && len(s.waiting) == 0 {
+		s.running++
+		s.mtx.Unlock()
+		return nil
+	}
+	if mayReject && s.maxWaiting > 0 && len(s.waiting) >= s.maxWaiting {
+		s.mtx.Unlock()
+		UpstreamBusy.Add(1)
+		return ErrUpstreamBusy
+	}
+
+	ready := make(chan struct{})
+	s.waiting = append(s.waiting, ready)
+	s.mtx.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for i, ch := range s.waiting {
+		if ch == ready {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			return ctx.Err()
+		}
+	}
+
+	// We were admitted just as the context ended; pass the turn on.
+	s.releaseLocked()
+	return ctx.Err()
+}
+
+func (s *scheduler) release() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.releaseLocked()
+}
+
+func (s *scheduler) releaseLocked() {
+	if len(s.waiting) != 0 {
+		// The slot goes straight to the next caller.
+		close(s.waiting[0])
+		s.waiting = s.waiting[1:]
+		return
+	}
+	s.running--
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+// loadTLSConfig returns the TLS configuration from the flags, or nil to
+// serve plain HTTP.
+//
+// With -tls-self-signed, a certificate is made up if the files don't exist
+// yet, and saved to them so browsers only have to accept it once. Without
+// any files, a new one is made every run.
+func loadTLSConfig() (*tls.Config, error) {
+	certFile, keyFile := *flagTLSCert, *flagTLSKey
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("-tls-cert and -tls-key must be given together")
+	}
+	if certFile == "" && !*flagTLSSelfSigned {
+		return nil, nil
+	}
+
+	var cert tls.Certificate
+	var err error
+	if certFile != "" {
+		cert, err = tls.LoadX509KeyPair(certFile, keyFile)
+		if !os.IsNotExist(err) || !*flagTLSSelfSigned {
+			if err != nil {
+				return nil, err
+			}
+			return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+		}
+	}
+
+	certPEM, keyPEM, err := selfSignedCert()
+	if err != nil {
+		return nil, err
+	}
+	if cert, err = tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, err
+	}
+
+	if certFile != "" {
+		if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	// Let people check that they're talking to us before trusting it.
+	fingerprint := sha256.Sum256(cert.Certificate[0])
+	log.Println("self-signed certificate SHA-256 fingerprint:", hex.EncodeToString(fingerprint[:]))
+
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
+
+// selfSignedCert makes a certificate for the names this machine is likely
+// to be reached by on a LAN, and returns it and its key as PEM.
+func selfSignedCert() (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"armok_web"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	if host, err := os.Hostname(); err == nil {
+		template.DNSNames = append(template.DNSNames, host)
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, a := range addrs {
+			if ipnet, ok := a.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
+				template.IPAddresses = append(template.IPAddresses, ipnet.IP)
+			}
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
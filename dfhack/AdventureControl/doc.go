@@ -0,0 +1,3 @@
+//go:generate protoc -I. -I../RemoteFortressReader --go_out=. --go_opt=paths=source_relative AdventureControl.proto
+
+package AdventureControl
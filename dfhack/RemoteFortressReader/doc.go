@@ -1,3 +1,3 @@
-//go:generate protoc --go_out=. RemoteFortressReader.proto
+//go:generate protoc --go_out=. --go_opt=paths=source_relative RemoteFortressReader.proto
 
 package RemoteFortressReader
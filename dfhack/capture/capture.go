@@ -0,0 +1,298 @@
+// Package capture records DFHack sessions to a file and replays them
+// without Dwarf Fortress running.
+//
+// A capture file is a gzip stream starting with the line "dfhack capture 2",
+// followed by one record per call in the order the replies arrived. Each
+// record is a sequence of unsigned varints and length-prefixed byte
+// strings:
+//
+//	conn, address, plugin, method, input type, output type,
+//	start (µs), end (µs), request, number of text notifications,
+//	each text notification, command result (zig-zag), result
+//
+// Times are measured from the start of the recording. Files starting with
+// "dfhack capture 1" have no address.
+package capture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	magic   = "dfhack capture 2\n"
+	magicV1 = "dfhack capture 1\n"
+)
+
+// maxSize is the largest message DFHack will send, which also bounds every
+// byte string in a capture file.
+const maxSize = 8 * 1048576
+
+var ErrFormat = errors.New("capture: not a capture file")
+
+// A Call is one request and its reply.
+type Call struct {
+	// Conn numbers the sockets of a recording in the order they were
+	// dialed, starting at 0. A Conn that reconnects uses a new socket.
+	Conn int
+
+	// Addr is the address the socket was dialed to. It is empty in
+	// files from before addresses were recorded.
+	Addr string
+
+	Plugin string // empty for core methods
+	Method string
+
+	// In and Out are the message names from the call's BindMethod
+	// request, such as "dfproto.EmptyMessage".
+	In  string
+	Out string
+
+	// Start is when the request was sent and End is when the reply
+	// finished arriving, both since the start of the recording.
+	Start time.Duration
+	End   time.Duration
+
+	Request []byte // serialized input message
+	Text    []*dfproto.CoreTextNotification
+	Code    int32  // the CR_* command result if the call failed, or 0
+	Result  []byte // serialized output message
+}
+
+// A Writer writes calls to a capture file.
+type Writer struct {
+	z       *gzip.Writer
+	buf     []byte
+	scratch [binary.MaxVarintLen64]byte
+	err     error
+}
+
+// NewWriter returns a Writer that writes a capture file to w. The file is
+// not complete until the Writer is closed.
+func NewWriter(w io.Writer) *Writer {
+	cw := &Writer{z: gzip.NewWriter(w)}
+	_, cw.err = io.WriteString(cw.z, magic)
+	return cw
+}
+
+func (w *Writer) uvarint(x uint64) {
+	n := binary.PutUvarint(w.scratch[:], x)
+	w.buf = append(w.buf, w.scratch[:n]...)
+}
+
+func (w *Writer) varint(x int64) {
+	n := binary.PutVarint(w.scratch[:], x)
+	w.buf = append(w.buf, w.scratch[:n]...)
+}
+
+func (w *Writer) bytes(b []byte) {
+	w.uvarint(uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+// Write appends c to the capture file.
+func (w *Writer) Write(c *Call) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.buf = w.buf[:0]
+	w.uvarint(uint64(c.Conn))
+	w.bytes([]byte(c.Addr))
+	w.bytes([]byte(c.Plugin))
+	w.bytes([]byte(c.Method))
+	w.bytes([]byte(c.In))
+	w.bytes([]byte(c.Out))
+	w.uvarint(uint64(c.Start / time.Microsecond))
+	w.uvarint(uint64(c.End / time.Microsecond))
+	w.bytes(c.Request)
+	w.uvarint(uint64(len(c.Text)))
+	for _, t := range c.Text {
+		b, err := proto.Marshal(t)
+		if err != nil {
+			return err
+		}
+		w.bytes(b)
+	}
+	w.varint(int64(c.Code))
+	w.bytes(c.Result)
+
+	_, w.err = w.z.Write(w.buf)
+	return w.err
+}
+
+// Flush writes any buffered calls to the underlying writer.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.z.Flush()
+	return w.err
+}
+
+// Close finishes the capture file. It does not close the underlying
+// writer.
+func (w *Writer) Close() error {
+	err := w.z.Close()
+	if w.err != nil {
+		return w.err
+	}
+	return err
+}
+
+// A Reader reads calls from a capture file.
+type Reader struct {
+	r      *bufio.Reader
+	noAddr bool // version 1
+}
+
+// NewReader checks that r contains a capture file and returns a Reader for
+// its calls.
+func NewReader(r io.Reader) (*Reader, error) {
+	z, err := gzip.NewReader(r)
+	if err != nil {
+		if err == gzip.ErrHeader {
+			err = ErrFormat
+		}
+		return nil, err
+	}
+
+	br := bufio.NewReader(z)
+	line, err := br.ReadString('\n')
+	if err != nil || (line != magic && line != magicV1) {
+		return nil, ErrFormat
+	}
+
+	return &Reader{r: br, noAddr: line == magicV1}, nil
+}
+
+func (r *Reader) uvarint() (uint64, error) {
+	return binary.ReadUvarint(r.r)
+}
+
+func (r *Reader) bytes() ([]byte, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > maxSize {
+		return nil, ErrFormat
+	}
+	b := make([]byte, n)
+	_, err = io.ReadFull(r.r, b)
+	return b, err
+}
+
+func (r *Reader) string() (string, error) {
+	b, err := r.bytes()
+	return string(b), err
+}
+
+// Next returns the next call in the file, or io.EOF after the last one.
+// A file whose Writer was never closed, such as one left by a program that
+// was killed, ends after the last call that was flushed.
+func (r *Reader) Next() (*Call, error) {
+	conn, err := r.uvarint()
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Past the first field, the end of the file means the record was
+	// cut off.
+	c, err := r.next(int(conn))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return c, err
+}
+
+func (r *Reader) next(conn int) (c *Call, err error) {
+	c = &Call{Conn: conn}
+
+	if !r.noAddr {
+		if c.Addr, err = r.string(); err != nil {
+			return
+		}
+	}
+	if c.Plugin, err = r.string(); err != nil {
+		return
+	}
+	if c.Method, err = r.string(); err != nil {
+		return
+	}
+	if c.In, err = r.string(); err != nil {
+		return
+	}
+	if c.Out, err = r.string(); err != nil {
+		return
+	}
+
+	var start, end uint64
+	if start, err = r.uvarint(); err != nil {
+		return
+	}
+	if end, err = r.uvarint(); err != nil {
+		return
+	}
+	c.Start = time.Duration(start) * time.Microsecond
+	c.End = time.Duration(end) * time.Microsecond
+
+	if c.Request, err = r.bytes(); err != nil {
+		return
+	}
+
+	var n uint64
+	if n, err = r.uvarint(); err != nil {
+		return
+	}
+	for ; n > 0; n-- {
+		var b []byte
+		if b, err = r.bytes(); err != nil {
+			return
+		}
+		t := new(dfproto.CoreTextNotification)
+		if err = proto.Unmarshal(b, t); err != nil {
+			return
+		}
+		c.Text = append(c.Text, t)
+	}
+
+	var code int64
+	if code, err = binary.ReadVarint(r.r); err != nil {
+		return
+	}
+	c.Code = int32(code)
+
+	c.Result, err = r.bytes()
+	return
+}
+
+// ReadFile reads every call in a capture file.
+func ReadFile(r io.Reader) ([]*Call, error) {
+	cr, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []*Call
+	for {
+		c, err := cr.Next()
+		if err == io.EOF {
+			return calls, nil
+		}
+		if err != nil {
+			return calls, err
+		}
+		calls = append(calls, c)
+	}
+}
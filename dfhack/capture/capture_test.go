@@ -0,0 +1,129 @@
+package capture_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/capture"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestRecordReplay(t *testing.T) {
+	s := dfhacktest.NewServer()
+	defer s.Close()
+	s.Reply("", "GetVersion", &dfproto.StringMessage{Value: proto.String("0.47.05-r1")})
+	s.Handle("RemoteFortressReader", "GetBlockList", func(req proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{
+			Text:   []*dfproto.CoreTextNotification{dfhacktest.Text("blocks")},
+			Result: &RemoteFortressReader.BlockList{MapX: req.(*RemoteFortressReader.BlockRequest).MinX},
+		}
+	})
+	s.Fail("", "GetDFVersion", dfhacktest.WrongUsage, "no")
+
+	var buf bytes.Buffer
+	rec := capture.NewRecorder(&buf)
+	d := &dfhack.Dialer{DialSocket: rec.DialSocket(s.DialSocket)}
+
+	c, err := d.Dial("fortress1:5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _ = c.GetVersion()
+	for i := int32(0); i < 3; i++ {
+		_, _, _ = c.GetBlockList(&RemoteFortressReader.BlockRequest{MinX: proto.Int32(i)})
+	}
+	_, _, _ = c.GetDFVersion()
+	_ = c.Close()
+
+	c, err = d.Dial("fortress2:5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, _ = c.GetVersion()
+	_ = c.Close()
+
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	calls, err := capture.ReadFile(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fortress1 []*capture.Call
+	for _, call := range calls {
+		switch {
+		case call.Conn == 0 && call.Addr == "fortress1:5000":
+			fortress1 = append(fortress1, call)
+		case call.Conn == 1 && call.Addr == "fortress2:5000":
+		default:
+			t.Errorf("call %s on connection %d recorded for %q", call.Method, call.Conn, call.Addr)
+		}
+	}
+
+	rs := capture.NewServer(fortress1, false)
+	defer rs.Close()
+	c, err = rs.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if v, _, err := c.GetVersion(); err != nil || v != "0.47.05-r1" {
+		t.Errorf("GetVersion: got %q, %v", v, err)
+	}
+
+	// A request that was recorded gets its own reply, repeatedly.
+	for i := 0; i < 2; i++ {
+		list, text, err := c.GetBlockList(&RemoteFortressReader.BlockRequest{MinX: proto.Int32(2)})
+		if err != nil || list.GetMapX() != 2 || len(text) != 1 {
+			t.Errorf("GetBlockList: got %v, %v, %v", list, text, err)
+		}
+	}
+
+	if _, _, err := c.GetDFVersion(); !errors.Is(err, dfhack.ErrWrongUsage) {
+		t.Errorf("GetDFVersion: got %v, want %v", err, dfhack.ErrWrongUsage)
+	}
+
+	// Methods that were never called can't be bound.
+	if _, err := c.ResetMapHashes(); !errors.Is(err, dfhack.ErrFailure) {
+		t.Errorf("ResetMapHashes: got %v, want %v", err, dfhack.ErrFailure)
+	}
+}
+
+func TestReadVersion1(t *testing.T) {
+	var buf bytes.Buffer
+	z := gzip.NewWriter(&buf)
+	_, _ = z.Write([]byte("dfhack capture 1\n"))
+	_, _ = z.Write([]byte{
+		3,                // conn
+		0,                // plugin
+		3, 'G', 'e', 't', // method
+		1, 'a', // input type
+		1, 'b', // output type
+		10, 20, // start, end
+		1, 0xff, // request
+		0,             // text notifications
+		3,             // command result -2
+		2, 0xfe, 0xfd, // result
+	})
+	_ = z.Close()
+
+	calls, err := capture.ReadFile(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("got %d calls, want 1", len(calls))
+	}
+	c := calls[0]
+	if c.Conn != 3 || c.Addr != "" || c.Method != "Get" || c.In != "a" || c.Out != "b" || c.Code != -2 || !bytes.Equal(c.Result, []byte{0xfe, 0xfd}) {
+		t.Errorf("got %+v", *c)
+	}
+}
@@ -0,0 +1,295 @@
+package capture
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+// A Recorder writes the calls made on sockets it wraps to a capture file.
+// It reads the DFHack protocol as it passes through, so it works with any
+// Conn and doesn't change what is sent.
+type Recorder struct {
+	mtx    sync.Mutex
+	w      *Writer
+	start  time.Time
+	conns  int
+	closed bool
+	err    error
+}
+
+// NewRecorder returns a Recorder that writes a capture file to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		w:     NewWriter(w),
+		start: time.Now(),
+	}
+}
+
+// Err returns the first error writing the capture file, if any. Calls made
+// after an error are not recorded.
+func (r *Recorder) Err() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.err
+}
+
+// Close finishes the capture file. Calls that complete afterwards are not
+// recorded.
+func (r *Recorder) Close() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.closed {
+		return r.err
+	}
+	r.closed = true
+
+	if err := r.w.Close(); r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+func (r *Recorder) write(c *Call) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.closed || r.err != nil {
+		return
+	}
+	if r.err = r.w.Write(c); r.err == nil {
+		// Keep the file useful if the program crashes.
+		r.err = r.w.Flush()
+	}
+}
+
+// DialSocket wraps dial, or dfhack.DialSocket if it is nil, so that the
+// sockets it returns are recorded. The result can be used as a
+// dfhack.Dialer's DialSocket.
+func (r *Recorder) DialSocket(dial func(ctx context.Context, addr string) (io.ReadWriteCloser, error)) func(ctx context.Context, addr string) (io.ReadWriteCloser, error) {
+	if dial == nil {
+		dial = dfhack.DialSocket
+	}
+
+	return func(ctx context.Context, addr string) (io.ReadWriteCloser, error) {
+		sock, err := dial(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		r.mtx.Lock()
+		conn := r.conns
+		r.conns++
+		r.mtx.Unlock()
+
+		s := &socket{
+			ReadWriteCloser: sock,
+			r:               r,
+			conn:            conn,
+			addr:            addr,
+			bound:           make(map[int16]*Call),
+		}
+
+		// The Conn only uses deadlines if the socket has them.
+		if d, ok := sock.(deadliner); ok {
+			return struct {
+				*socket
+				deadliner
+			}{s, d}, nil
+		}
+		return s, nil
+	}
+}
+
+type deadliner interface {
+	SetDeadline(time.Time) error
+	SetWriteDeadline(time.Time) error
+}
+
+const (
+	handshakeSize = 12
+	headerSize    = 8
+
+	rpcReplyResult int16 = -1
+	rpcReplyFail   int16 = -2
+	rpcReplyText   int16 = -3
+)
+
+type socket struct {
+	io.ReadWriteCloser
+	r    *Recorder
+	conn int
+	addr string
+
+	mtx sync.Mutex
+
+	// Bytes that have been written or read but don't make up a whole
+	// message yet, and whether the handshake has been seen.
+	out, in           []byte
+	outShook, inShook bool
+
+	// bound holds the method of every id assigned so far, as a Call
+	// with only the method fields set.
+	bound map[int16]*Call
+
+	// Calls waiting for their reply, in order.
+	pending []*Call
+}
+
+func (s *socket) Write(b []byte) (int, error) {
+	// The request has to be queued before the reply can arrive. If the
+	// write fails, the Conn won't use the socket again anyway.
+	s.mtx.Lock()
+	s.out = append(s.out, b...)
+	s.parseRequests()
+	s.mtx.Unlock()
+
+	return s.ReadWriteCloser.Write(b)
+}
+
+func (s *socket) Read(b []byte) (int, error) {
+	n, err := s.ReadWriteCloser.Read(b)
+
+	s.mtx.Lock()
+	s.in = append(s.in, b[:n]...)
+	s.parseReplies()
+	s.mtx.Unlock()
+
+	return n, err
+}
+
+// next removes the next message from buf if it is complete. Failure
+// replies have no body.
+func next(buf *[]byte) (id int16, size int32, body []byte, ok bool) {
+	if len(*buf) < headerSize {
+		return
+	}
+
+	id = int16(binary.LittleEndian.Uint16((*buf)[0:]))
+	size = int32(binary.LittleEndian.Uint32((*buf)[4:]))
+
+	n := headerSize
+	if id != rpcReplyFail && size > 0 {
+		n += int(size)
+	}
+	if len(*buf) < n {
+		return
+	}
+
+	body = append([]byte(nil), (*buf)[headerSize:n]...)
+	*buf = (*buf)[n:]
+	return id, size, body, true
+}
+
+func (s *socket) parseRequests() {
+	if !s.outShook {
+		if len(s.out) < handshakeSize {
+			return
+		}
+		s.out = s.out[handshakeSize:]
+		s.outShook = true
+	}
+
+	for {
+		id, _, body, ok := next(&s.out)
+		if !ok {
+			return
+		}
+		if id < 0 {
+			// RPC_REQUEST_QUIT; nothing to record.
+			continue
+		}
+
+		c := &Call{
+			Conn:    s.conn,
+			Addr:    s.addr,
+			Start:   time.Since(s.r.start),
+			Request: body,
+		}
+
+		switch id {
+		case 0:
+			c.Method, c.In, c.Out = "BindMethod", "dfproto.CoreBindRequest", "dfproto.CoreBindReply"
+		case 1:
+			c.Method, c.In, c.Out = "RunCommand", "dfproto.CoreRunCommandRequest", "dfproto.EmptyMessage"
+		}
+		if m, ok := s.bound[id]; ok {
+			c.Plugin, c.Method, c.In, c.Out = m.Plugin, m.Method, m.In, m.Out
+		} else if c.Method == "" {
+			c.Method = fmt.Sprintf("#%d", id)
+		}
+
+		s.pending = append(s.pending, c)
+	}
+}
+
+func (s *socket) parseReplies() {
+	if !s.inShook {
+		if len(s.in) < handshakeSize {
+			return
+		}
+		s.in = s.in[handshakeSize:]
+		s.inShook = true
+	}
+
+	for {
+		id, size, body, ok := next(&s.in)
+		if !ok {
+			return
+		}
+		if len(s.pending) == 0 {
+			// The Conn will fail the socket; there's nothing to
+			// record.
+			continue
+		}
+
+		c := s.pending[0]
+		switch id {
+		case rpcReplyText:
+			t := new(dfproto.CoreTextNotification)
+			if proto.Unmarshal(body, t) == nil {
+				c.Text = append(c.Text, t)
+			}
+			continue
+		case rpcReplyFail:
+			c.Code = size
+		case rpcReplyResult:
+			c.Result = body
+		default:
+			continue
+		}
+
+		s.pending = s.pending[1:]
+		c.End = time.Since(s.r.start)
+
+		if c.Plugin == "" && c.Method == "BindMethod" && c.Code == 0 {
+			s.bind(c)
+		}
+
+		s.r.write(c)
+	}
+}
+
+func (s *socket) bind(c *Call) {
+	var req dfproto.CoreBindRequest
+	var reply dfproto.CoreBindReply
+	if proto.Unmarshal(c.Request, &req) != nil || proto.Unmarshal(c.Result, &reply) != nil {
+		return
+	}
+
+	s.bound[int16(reply.GetAssignedId())] = &Call{
+		Plugin: req.GetPlugin(),
+		Method: req.GetMethod(),
+		In:     req.GetInputMsg(),
+		Out:    req.GetOutputMsg(),
+	}
+}
@@ -0,0 +1,131 @@
+package capture
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/golang/protobuf/proto"
+)
+
+// NewServer returns a fake DFHack server that answers calls with the
+// replies in calls. Methods that were never called in the recording can't
+// be bound.
+//
+// Each method replays its recorded calls in order. A call whose request
+// matches a later recorded request exactly skips ahead to it, so that
+// requests with arguments (like GetBlockList) get the right reply. Once a
+// method's calls run out, its last reply is repeated.
+//
+// If realTime is set, every reply is delayed by as long as the recorded
+// one took.
+func NewServer(calls []*Call, realTime bool) *dfhacktest.Server {
+	s := dfhacktest.NewServer()
+
+	type key struct{ plugin, method string }
+	var order []key
+	byMethod := make(map[key][]*Call)
+	for _, c := range calls {
+		if c.Plugin == "" && c.Method == "BindMethod" {
+			// The server assigns its own ids.
+			continue
+		}
+		if c.In == "" || c.Out == "" {
+			// The method was never bound, so it can't be replayed.
+			continue
+		}
+
+		k := key{c.Plugin, c.Method}
+		if _, ok := byMethod[k]; !ok {
+			order = append(order, k)
+		}
+		byMethod[k] = append(byMethod[k], c)
+	}
+
+	for _, k := range order {
+		calls := byMethod[k]
+		r := &replayer{calls: calls, realTime: realTime}
+
+		_ = s.HandleMethod(dfhack.Method{
+			Name:   k.method,
+			Plugin: k.plugin,
+			In:     calls[0].In,
+			Out:    calls[0].Out,
+			NewIn:  func() proto.Message { return new(rawMessage) },
+			NewOut: func() proto.Message { return new(rawMessage) },
+		}, r.reply)
+	}
+
+	return s
+}
+
+type replayer struct {
+	mtx      sync.Mutex
+	calls    []*Call
+	next     int
+	realTime bool
+}
+
+func (r *replayer) reply(req proto.Message) dfhacktest.Reply {
+	b := req.(*rawMessage).b
+
+	r.mtx.Lock()
+	c := r.find(b)
+	r.mtx.Unlock()
+
+	reply := dfhacktest.Reply{
+		Text:   c.Text,
+		Result: &rawMessage{b: c.Result},
+		Code:   c.Code,
+	}
+	if r.realTime {
+		reply.Delay = c.End - c.Start
+	}
+	return reply
+}
+
+func (r *replayer) find(req []byte) *Call {
+	for i := r.next; i < len(r.calls); i++ {
+		if bytes.Equal(r.calls[i].Request, req) {
+			r.next = i + 1
+			return r.calls[i]
+		}
+	}
+
+	if r.next < len(r.calls) {
+		r.next++
+		return r.calls[r.next-1]
+	}
+
+	// Out of calls: repeat the last reply to the same request, if there
+	// was one.
+	for i := len(r.calls) - 1; i >= 0; i-- {
+		if bytes.Equal(r.calls[i].Request, req) {
+			return r.calls[i]
+		}
+	}
+	return r.calls[len(r.calls)-1]
+}
+
+// rawMessage is a message that is passed through without being decoded.
+type rawMessage struct {
+	b []byte
+}
+
+func (m *rawMessage) Reset()         { m.b = nil }
+func (m *rawMessage) String() string { return string(m.b) }
+func (*rawMessage) ProtoMessage()    {}
+
+func (m *rawMessage) Marshal() ([]byte, error) {
+	return m.b, nil
+}
+
+func (m *rawMessage) Unmarshal(b []byte) error {
+	if m == nil {
+		return errors.New("capture: Unmarshal into nil rawMessage")
+	}
+	m.b = append(m.b[:0], b...)
+	return nil
+}
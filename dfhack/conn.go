@@ -1,10 +1,14 @@
 package dfhack
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/BenLubar/arm_ok/dfhack/dfproto"
 	"github.com/golang/protobuf/proto"
@@ -14,6 +18,8 @@ var (
 	ErrInvalidHandshake = errors.New("dfhack: invalid handshake")
 	ErrMessageTooLarge  = errors.New("dfhack: message too large")
 	ErrInvalidError     = errors.New("dfhack: error code unknown")
+	ErrBrokenConn       = errors.New("dfhack: connection is unusable after an I/O error or interrupted call")
+	ErrClosed           = errors.New("dfhack: use of closed connection")
 
 	ErrLinkFailure    = errors.New("dfhack: CR_LINK_FAILURE: RPC call failed due to I/O or protocol error")
 	ErrNeedsConsole   = errors.New("dfhack: CR_NEEDS_CONSOLE: attempt to call interactive command without console")
@@ -32,11 +38,115 @@ var knownErrors = map[int32]error{
 	3: ErrNotFound,
 }
 
+// An RPCError is returned when DFHack reports that a call failed. It
+// matches the corresponding sentinel error (such as ErrFailure) with
+// errors.Is, or ErrInvalidError if the code is unknown.
+type RPCError struct {
+	Method string
+	Plugin string // empty for core methods
+	Code   int32  // the CR_* command result
+
+	// Text is the concatenated text of the notifications sent with the
+	// failure, which usually explains it.
+	Text string
+}
+
+func (e *RPCError) Error() string {
+	name := e.Method
+	if e.Plugin != "" {
+		name = e.Plugin + "::" + e.Method
+	}
+
+	var reason string
+	if known, ok := knownErrors[e.Code]; ok {
+		reason = strings.TrimPrefix(known.Error(), "dfhack: ")
+	} else {
+		reason = fmt.Sprintf("%s (%d)", strings.TrimPrefix(ErrInvalidError.Error(), "dfhack: "), e.Code)
+	}
+
+	if text := strings.TrimSpace(e.Text); text != "" {
+		return "dfhack: " + name + ": " + reason + ": " + text
+	}
+	return "dfhack: " + name + ": " + reason
+}
+
+func (e *RPCError) Is(target error) bool {
+	if known, ok := knownErrors[e.Code]; ok {
+		return target == known
+	}
+	return target == ErrInvalidError
+}
+
 type Conn struct {
+	mtx    sync.Mutex
+	stream *stream // nil while disconnected
+	closed bool
+
+	dialer      Dialer
+	interceptor Interceptor
+	addr        string
+
+	// redialing is closed when the reconnect in progress, if any, is
+	// over. cancelDial interrupts it when the connection is closed.
+	redialing  chan struct{}
+	dialCtx    context.Context
+	cancelDial context.CancelFunc
+	backoff    time.Duration
+	retryAt    time.Time
+}
+
+// A stream is a single connected socket. Method IDs are only valid on the
+// socket that bound them, so they are stored here rather than on the Conn.
+type stream struct {
 	sock   io.ReadWriteCloser
 	bound  map[[3]string]int16
 	plugin map[string]map[[3]string]int16
-	mtx    sync.Mutex
+
+	// Calls that have been sent and are waiting for a reply, in the
+	// order they were sent. The server replies to calls in order.
+	pending []*call
+
+	// err is set once the socket has failed; the stream can't be used
+	// after that.
+	err error
+
+	// wlock is held (by sending to it) while a message is being written
+	// to sock. The connection isn't locked during writes, so the reader
+	// can keep completing calls while a write is blocked.
+	wlock chan struct{}
+
+	// pushes holds the handlers for subscriptions, by method id.
+	pushes map[int16]PushHandler
+
+	// Pushes are queued by the reader and delivered in order by
+	// deliver, so a slow handler doesn't hold up replies. pushReady is
+	// signalled when pushQueue becomes non-empty, and closed when the
+	// stream fails.
+	pushQueue []pushed
+	pushReady chan struct{}
+}
+
+type pushed struct {
+	id     int16
+	result []byte
+}
+
+type call struct {
+	text   []*dfproto.CoreTextNotification
+	result []byte
+	code   int32
+	err    error
+	done   chan struct{}
+
+	// subscribed is set if the server acknowledged a subscription.
+	subscribed bool
+
+	// If handler is non-nil, text is passed to it instead of being
+	// collected. mtx is held while it runs so that it is never called
+	// after the call is abandoned.
+	handler   TextHandler
+	mtx       sync.Mutex
+	abandoned bool
 }
 
 var (
@@ -58,6 +168,18 @@ const (
 	rpcReplyFail   int16 = -2
 	rpcReplyText   int16 = -3
 	rpcRequestQuit int16 = -4
+
+	// rpcReplyPush is an armok_web extension. Its padding holds the id
+	// of the method the pushed result is for.
+	rpcReplyPush int16 = -5
+)
+
+// armok_web uses the first byte of a request's padding to subscribe to or
+// unsubscribe from a method, and sets it in the result to acknowledge a
+// subscription. DFHack ignores it.
+const (
+	padSubscribe   byte = 1
+	padUnsubscribe byte = 2
 )
 
 type rpcMessageHeader struct {
@@ -72,20 +194,25 @@ type rpcMessageHeader struct {
 //   request header. The server responds with the response
 //   magic. Currently both versions must be 1.
 //
-func (c *Conn) init() (self *Conn, err error) {
-	self = c
+func handshake(ctx context.Context, sock io.ReadWriteCloser) (err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	var setDeadline func(time.Time) error
+	if d, ok := sock.(interface {
+		SetDeadline(time.Time) error
+	}); ok {
+		setDeadline = d.SetDeadline
+	}
+
+	stop := watch(ctx, sock, setDeadline)
 	defer func() {
-		if err != nil {
-			// clean up if we fail to initialize.
-			c.Close()
-			self = nil
-		}
+		stop()
+		err = contextError(ctx, err)
 	}()
 
-	c.bound = make(map[[3]string]int16)
-	c.plugin = make(map[string]map[[3]string]int16)
-
-	err = binary.Write(c.sock, binary.LittleEndian, &rpcHandshakeHeader{
+	err = binary.Write(sock, binary.LittleEndian, &rpcHandshakeHeader{
 		Magic:   rpcMagicRequest,
 		Version: rpcVersion,
 	})
@@ -94,7 +221,7 @@ func (c *Conn) init() (self *Conn, err error) {
 	}
 
 	var response rpcHandshakeHeader
-	err = binary.Read(c.sock, binary.LittleEndian, &response)
+	err = binary.Read(sock, binary.LittleEndian, &response)
 	if err != nil {
 		return
 	}
@@ -128,7 +255,63 @@ func (c *Conn) init() (self *Conn, err error) {
 //   of the function if it succeeded, or RPC_REPLY_FAIL with the
 //   error code if it did not.
 //
-func (c *Conn) roundTrip(id int16, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+//   The server handles one call at a time, in order, so we can
+//   write further requests before the reply to the first one
+//   arrives and match each reply with the oldest pending call.
+//
+func (c *Conn) roundTrip(ctx context.Context, id int16, method string, plugin *string, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	m := describe(method, plugin, "", "")
+	return c.intercept(ctx, m, req, resp, func(ctx context.Context, m1 Method, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+		if m1.Name != m.Name || m1.Plugin != m.Plugin {
+			return c.invoke(ctx, m1, req, resp)
+		}
+		return c.roundTripID(ctx, id, method, plugin, req, resp)
+	})
+}
+
+func (c *Conn) roundTripID(ctx context.Context, id int16, method string, plugin *string, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	for {
+		s, err := c.current(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := c.roundTripOn(ctx, s, id, method, plugin, req, resp)
+		if err == errStaleStream {
+			continue
+		}
+		return text, err
+	}
+}
+
+// errStaleStream is returned by roundTripOn if the stream failed before the
+// request could be sent, meaning it is safe to try again on a new one.
+var errStaleStream = errors.New("dfhack: stream closed before request was sent")
+
+func (c *Conn) roundTripOn(ctx context.Context, s *stream, id int16, method string, plugin *string, req, resp proto.Message) (text []*dfproto.CoreTextNotification, err error) {
+	var cs *CallStats
+	if stats := c.dialer.Stats; stats != nil {
+		cs = &CallStats{
+			Method: method,
+			Start:  time.Now(),
+		}
+		if id == 0 {
+			cs.Method = "BindMethod"
+		} else if plugin != nil {
+			cs.Plugin = *plugin
+		}
+		defer func() {
+			if err == errStaleStream {
+				// The call will be retried.
+				return
+			}
+			cs.End = time.Now()
+			cs.Text = len(text)
+			cs.Err = err
+			stats.HandleCall(cs)
+		}()
+	}
+
 	b, err := proto.Marshal(req)
 	if err != nil {
 		return nil, err
@@ -137,68 +320,350 @@ func (c *Conn) roundTrip(id int16, req, resp proto.Message) ([]*dfproto.CoreText
 	if len(b) > int(maxMessageSize) {
 		return nil, ErrMessageTooLarge
 	}
+	if cs != nil {
+		cs.RequestSize = len(b)
+	}
 
+	handler, _ := ctx.Value(textHandlerKey{}).(TextHandler)
+	if handler == nil {
+		handler = c.dialer.TextHandler
+	}
+
+	var pad [2]byte
+	sub, _ := ctx.Value(subscriptionKey{}).(*subscription)
+	if sub != nil && id != 0 && sub.method == method && sub.plugin == pluginName(plugin) {
+		if sub.push == nil {
+			pad[0] = padUnsubscribe
+		} else {
+			pad[0] = padSubscribe
+		}
+		// Register the handler before subscribing so that no push is
+		// missed.
+		c.mtx.Lock()
+		if sub.push == nil {
+			delete(s.pushes, id)
+		} else {
+			s.pushes[id] = sub.push
+		}
+		c.mtx.Unlock()
+	} else {
+		sub = nil
+	}
+
+	cl, err := c.send(ctx, s, id, b, pad, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	if sub != nil && sub.push != nil {
+		defer func() {
+			c.mtx.Lock()
+			if !cl.subscribed {
+				delete(s.pushes, id)
+			}
+			c.mtx.Unlock()
+		}()
+	}
+
+	select {
+	case <-cl.done:
+	case <-ctx.Done():
+		// The reader will still consume the reply when it arrives,
+		// so abandoning the call leaves the stream in sync.
+		cl.mtx.Lock()
+		cl.abandoned = true
+		cl.mtx.Unlock()
+		return nil, ctx.Err()
+	}
+
+	if cl.err != nil {
+		return cl.text, cl.err
+	}
+
+	if cl.code != 0 {
+		err := &RPCError{
+			Method: method,
+			Code:   cl.code,
+			Text:   textString(cl.text),
+		}
+		if plugin != nil {
+			err.Plugin = *plugin
+		}
+		return cl.text, err
+	}
+
+	if cs != nil {
+		cs.ResponseSize = len(cl.result)
+	}
+
+	if err := proto.Unmarshal(cl.result, resp); err != nil {
+		return cl.text, err
+	}
+
+	if sub != nil && sub.push != nil && !cl.subscribed {
+		return cl.text, ErrNoSubscriptions
+	}
+
+	return cl.text, nil
+}
+
+// send writes a request to the stream and queues a call to receive its
+// reply.
+func (c *Conn) send(ctx context.Context, s *stream, id int16, b []byte, pad [2]byte, handler TextHandler) (*call, error) {
+	select {
+	case s.wlock <- struct{}{}:
+		defer func() { <-s.wlock }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// The call is queued before the request is written so that the
+	// reader can't see the reply first. Holding wlock keeps the queue in
+	// the same order as the requests.
 	c.mtx.Lock()
-	defer c.mtx.Unlock()
+	if s.err != nil {
+		c.mtx.Unlock()
+		return nil, errStaleStream
+	}
+	cl := &call{done: make(chan struct{}), handler: handler}
+	s.pending = append(s.pending, cl)
+	c.mtx.Unlock()
+
+	var setDeadline func(time.Time) error
+	if d, ok := s.sock.(interface {
+		SetWriteDeadline(time.Time) error
+	}); ok {
+		setDeadline = d.SetWriteDeadline
+	}
 
-	err = binary.Write(c.sock, binary.LittleEndian, &rpcMessageHeader{
+	stop := watch(ctx, s.sock, setDeadline)
+	err := binary.Write(s.sock, binary.LittleEndian, &rpcMessageHeader{
 		ID:   id,
+		Pad:  pad,
 		Size: int32(len(b)),
 	})
-	if err != nil {
-		return nil, err
+	if err == nil && len(b) != 0 {
+		var n int
+		n, err = s.sock.Write(b)
+		if err == nil && n != len(b) {
+			err = io.ErrShortWrite
+		}
 	}
-
-	n, err := c.sock.Write(b)
-	if err == nil && n != len(b) {
-		err = io.ErrShortWrite
+	if closed := stop(); closed && err == nil {
+		err = ErrBrokenConn
 	}
 	if err != nil {
-		return nil, err
+		// We may have written part of the request, so nothing
+		// else can be sent on this socket.
+		c.mtx.Lock()
+		c.fail(s, err)
+		c.mtx.Unlock()
+		return nil, contextError(ctx, err)
 	}
 
-	var text []*dfproto.CoreTextNotification
+	return cl, nil
+}
 
+// read receives replies on the stream until it fails, completing pending
+// calls in order.
+func (c *Conn) read(s *stream) {
 	for {
 		var header rpcMessageHeader
-		err = binary.Read(c.sock, binary.LittleEndian, &header)
+		err := binary.Read(s.sock, binary.LittleEndian, &header)
+
+		var b []byte
+		if err == nil && header.ID != rpcReplyFail {
+			if header.Size < 0 || header.Size > maxMessageSize {
+				err = ErrMessageTooLarge
+			} else {
+				b = make([]byte, header.Size)
+				_, err = io.ReadFull(s.sock, b)
+			}
+		}
+
+		var text *dfproto.CoreTextNotification
+		if err == nil && header.ID == rpcReplyText {
+			text = new(dfproto.CoreTextNotification)
+			err = proto.Unmarshal(b, text)
+		}
+
+		if err == nil && header.ID == rpcReplyPush {
+			// Pushes aren't replies to any call, so they can arrive
+			// with nothing pending.
+			c.mtx.Lock()
+			if s.err == nil {
+				s.pushQueue = append(s.pushQueue, pushed{
+					id:     int16(binary.LittleEndian.Uint16(header.Pad[:])),
+					result: b,
+				})
+				select {
+				case s.pushReady <- struct{}{}:
+				default:
+				}
+			}
+			c.mtx.Unlock()
+			continue
+		}
+
+		c.mtx.Lock()
+		if err == nil && len(s.pending) == 0 {
+			err = ErrLinkFailure
+		}
 		if err != nil {
-			return text, err
+			c.fail(s, err)
+			c.mtx.Unlock()
+			return
 		}
 
+		cl := s.pending[0]
 		switch header.ID {
 		case rpcReplyResult:
-			b := make([]byte, header.Size)
-			_, err = io.ReadFull(c.sock, b)
-			if err != nil {
-				return text, err
-			}
-
-			return text, proto.Unmarshal(b, resp)
+			cl.result = b
+			cl.subscribed = header.Pad[0] == padSubscribe
+			s.pending = s.pending[1:]
+			close(cl.done)
 
 		case rpcReplyFail:
-			if err, ok := knownErrors[header.Size]; ok {
-				return text, err
-			}
-			return text, ErrInvalidError
+			cl.code = header.Size
+			s.pending = s.pending[1:]
+			close(cl.done)
 
 		case rpcReplyText:
-			var message dfproto.CoreTextNotification
-			b := make([]byte, header.Size)
-			_, err = io.ReadFull(c.sock, b)
-			if err != nil {
-				return text, err
+			if cl.handler == nil {
+				cl.text = append(cl.text, text)
+				break
 			}
 
-			err = proto.Unmarshal(b, &message)
-			if err != nil {
-				return text, err
+			// Don't hold the connection lock while the handler
+			// runs so other goroutines can keep sending.
+			c.mtx.Unlock()
+			cl.mtx.Lock()
+			if !cl.abandoned {
+				cl.handler(text)
 			}
-			text = append(text, &message)
+			cl.mtx.Unlock()
+			continue
 		}
+		c.mtx.Unlock()
 	}
 }
 
+// deliver passes pushes to their handlers until the stream fails.
+func (c *Conn) deliver(s *stream) {
+	for range s.pushReady {
+		for {
+			c.mtx.Lock()
+			if len(s.pushQueue) == 0 || s.err != nil {
+				s.pushQueue = nil
+				c.mtx.Unlock()
+				break
+			}
+			p := s.pushQueue[0]
+			s.pushQueue = s.pushQueue[1:]
+			// Look the handler up now, in case the method was
+			// unsubscribed while the push was queued.
+			push := s.pushes[p.id]
+			c.mtx.Unlock()
+
+			if push != nil {
+				push(p.result)
+			}
+		}
+	}
+}
+
+// fail closes the stream's socket and fails any pending calls. The
+// connection must be locked.
+func (c *Conn) fail(s *stream, err error) {
+	if s.err != nil {
+		return
+	}
+	if c.closed {
+		err = ErrClosed
+	}
+
+	s.err = err
+	_ = s.sock.Close()
+	close(s.pushReady)
+
+	// Report the disconnect before failing the calls, so their callers
+	// can tell what happened.
+	if c.stream == s {
+		c.stream = nil
+		if !c.closed {
+			c.setState(StateDisconnected, err)
+		}
+	}
+
+	for _, cl := range s.pending {
+		cl.err = err
+		close(cl.done)
+	}
+	s.pending = nil
+}
+
+// aLongTimeAgo is a non-zero time in the past, used to interrupt blocked
+// socket operations.
+var aLongTimeAgo = time.Unix(1, 0)
+
+// watch applies the deadline and cancellation of ctx to sock using
+// setDeadline until the returned function is called. Sockets that do not
+// support deadlines (setDeadline is nil) are closed on cancellation, in
+// which case the returned function reports true.
+func watch(ctx context.Context, sock io.Closer, setDeadline func(time.Time) error) func() (closed bool) {
+	if setDeadline != nil {
+		if t, ok := ctx.Deadline(); ok {
+			_ = setDeadline(t)
+		}
+	}
+
+	done := ctx.Done()
+	if done == nil {
+		return func() bool { return false }
+	}
+
+	stop := make(chan struct{})
+	exited := make(chan bool, 1)
+	go func() {
+		select {
+		case <-done:
+			if setDeadline != nil {
+				_ = setDeadline(aLongTimeAgo)
+				exited <- false
+			} else {
+				_ = sock.Close()
+				exited <- true
+			}
+		case <-stop:
+			exited <- false
+		}
+	}()
+
+	return func() bool {
+		close(stop)
+		closed := <-exited
+		if setDeadline != nil {
+			_ = setDeadline(time.Time{})
+		}
+		return closed
+	}
+}
+
+// contextError replaces errors caused by the socket being interrupted with
+// the error from ctx.
+func contextError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if t, ok := ctx.Deadline(); ok && !time.Now().Before(t) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
 // 3. Disconnect
 //
 //   The client terminates the connection by sending an
@@ -206,13 +671,38 @@ func (c *Conn) roundTrip(id int16, req, resp proto.Message) ([]*dfproto.CoreText
 //   closing the socket.
 //
 func (c *Conn) Close() error {
+	c.mtx.Lock()
+	if c.closed {
+		c.mtx.Unlock()
+		return ErrClosed
+	}
+	c.closed = true
+	s := c.stream
+	c.mtx.Unlock()
+
+	c.cancelDial()
+
+	if s != nil {
+		select {
+		case s.wlock <- struct{}{}:
+			_ = binary.Write(s.sock, binary.LittleEndian, &rpcMessageHeader{
+				ID:   rpcRequestQuit,
+				Size: 0,
+			})
+			<-s.wlock
+		default:
+			// A request is still being written, and closing the
+			// socket is the only way to interrupt it.
+		}
+	}
+
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 
-	_ = binary.Write(c.sock, binary.LittleEndian, &rpcMessageHeader{
-		ID:   rpcRequestQuit,
-		Size: 0,
-	})
+	if s != nil {
+		c.fail(s, ErrClosed)
+	}
+	c.setState(StateClosed, nil)
 
-	return c.sock.Close()
+	return nil
 }
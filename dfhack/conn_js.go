@@ -3,19 +3,77 @@
 package dfhack
 
 import (
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+
 	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/websocket"
 )
 
-func Connect() (*Conn, error) {
-	return Dial(js.Global.Get("location").Get("host").String())
+func defaultAddr() string {
+	return js.Global.Get("location").Get("host").String()
 }
 
-func Dial(addr string) (*Conn, error) {
-	sock, err := websocket.Dial("ws://" + addr + "/ws")
+// DialSocket opens a WebSocket to the armok_web proxy at addr. It is the
+// default for Dialer.DialSocket.
+func DialSocket(ctx context.Context, addr string) (io.ReadWriteCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Pass along the page's query string, which holds any token or
+	// invite the proxy needs, and ask for compressed replies.
+	search := js.Global.Get("location").Get("search").String()
+	if search == "" {
+		search = "?compress=deflate"
+	} else {
+		search += "&compress=deflate"
+	}
+
+	ws, err := websocket.Dial(socketURL(addr) + search)
 	if err != nil {
 		return nil, err
 	}
 
-	return (&Conn{sock: sock}).init()
+	return &compressedSocket{
+		Conn: ws,
+		// The handshake reply is sent before compression starts.
+		r: io.MultiReader(io.LimitReader(ws, int64(binary.Size(rpcHandshakeHeader{}))), flate.NewReader(ws)),
+	}, nil
+}
+
+// socketURL returns the address of the proxy's WebSocket, which is next to
+// the page, using wss:// if the page was loaded over HTTPS. That way it
+// works however the proxy is served, including under a path behind a
+// reverse proxy.
+func socketURL(addr string) string {
+	location := js.Global.Get("location")
+
+	scheme := "ws://"
+	if location.Get("protocol").String() == "https:" {
+		scheme = "wss://"
+	}
+
+	dir := location.Get("pathname").String()
+	dir = dir[:strings.LastIndexByte(dir, '/')+1]
+	if dir == "" {
+		dir = "/"
+	}
+
+	return scheme + addr + dir + "ws"
+}
+
+// compressedSocket reads the replies from the proxy through a deflate
+// stream.
+type compressedSocket struct {
+	net.Conn
+	r io.Reader
+}
+
+func (s *compressedSocket) Read(b []byte) (int, error) {
+	return s.r.Read(b)
 }
@@ -3,25 +3,25 @@
 package dfhack
 
 import (
+	"context"
+	"io"
 	"net"
 	"os"
 	"strconv"
 )
 
-func Connect() (*Conn, error) {
+func defaultAddr() string {
 	port, err := strconv.Atoi(os.Getenv("DFHACK_PORT"))
 	if err != nil {
 		port = 5000
 	}
 
-	return Dial(net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
 }
 
-func Dial(addr string) (*Conn, error) {
-	sock, err := net.Dial("tcp", addr)
-	if err != nil {
-		return nil, err
-	}
-
-	return (&Conn{sock: sock}).init()
+// DialSocket opens a TCP connection to addr. It is the default for
+// Dialer.DialSocket.
+func DialSocket(ctx context.Context, addr string) (io.ReadWriteCloser, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
 }
@@ -0,0 +1,292 @@
+package dfhack_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+// echoServer answers RunCommand with the command as text, after waiting as
+// many milliseconds as its first argument says.
+func echoServer() *dfhacktest.Server {
+	s := dfhacktest.NewServer()
+	s.Reply("", "GetVersion", &dfproto.StringMessage{Value: proto.String("0.47.05-r1")})
+	s.Handle("", "RunCommand", func(req proto.Message) dfhacktest.Reply {
+		r := req.(*dfproto.CoreRunCommandRequest)
+
+		var delay time.Duration
+		if len(r.Arguments) != 0 {
+			var ms int
+			_, _ = fmt.Sscan(r.Arguments[0], &ms)
+			delay = time.Duration(ms) * time.Millisecond
+		}
+
+		return dfhacktest.Reply{
+			Text:  []*dfproto.CoreTextNotification{dfhacktest.Text(r.GetCommand())},
+			Delay: delay,
+		}
+	})
+	return s
+}
+
+func runCommand(ctx context.Context, c *dfhack.Conn, command string, args ...string) (string, error) {
+	text, err := c.RunCommandContext(ctx, &dfproto.CoreRunCommandRequest{
+		Command:   proto.String(command),
+		Arguments: args,
+	})
+	var s string
+	for _, t := range text {
+		for _, f := range t.Fragments {
+			s += f.GetText()
+		}
+	}
+	return s, err
+}
+
+func TestPipelining(t *testing.T) {
+	s := echoServer()
+	defer s.Close()
+
+	c, err := s.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			want := fmt.Sprintf("command%d", i)
+			for j := 0; j < 10; j++ {
+				got, err := runCommand(context.Background(), c, want)
+				if err != nil || got != want {
+					t.Errorf("RunCommand %s: got %q, %v", want, got, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestCancel(t *testing.T) {
+	s := echoServer()
+	defer s.Close()
+
+	c, err := s.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := runCommand(ctx, c, "slow", "200"); err != context.DeadlineExceeded {
+		t.Fatalf("slow call: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The abandoned reply must not be mistaken for the next one.
+	if got, err := runCommand(context.Background(), c, "fast"); err != nil || got != "fast" {
+		t.Errorf("call after cancel: got %q, %v", got, err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := runCommand(ctx, c, "cancelled"); err != context.Canceled {
+		t.Errorf("call with cancelled context: got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRPCError(t *testing.T) {
+	tests := []struct {
+		code int32
+		want error
+	}{
+		{dfhacktest.LinkFailure, dfhack.ErrLinkFailure},
+		{dfhacktest.NeedsConsole, dfhack.ErrNeedsConsole},
+		{dfhacktest.NotImplemented, dfhack.ErrNotImplemented},
+		{dfhacktest.Failure, dfhack.ErrFailure},
+		{dfhacktest.WrongUsage, dfhack.ErrWrongUsage},
+		{dfhacktest.NotFound, dfhack.ErrNotFound},
+		{42, dfhack.ErrInvalidError},
+	}
+
+	s := dfhacktest.NewServer()
+	defer s.Close()
+
+	c, err := s.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	for _, tt := range tests {
+		s.Fail("RemoteFortressReader", "GetMapInfo", tt.code, "no world loaded")
+
+		_, _, err := c.GetMapInfo()
+		if !errors.Is(err, tt.want) {
+			t.Errorf("code %d: got %v, want %v", tt.code, err, tt.want)
+			continue
+		}
+
+		var rpcErr *dfhack.RPCError
+		if !errors.As(err, &rpcErr) {
+			t.Errorf("code %d: %T is not an *RPCError", tt.code, err)
+			continue
+		}
+		if rpcErr.Method != "GetMapInfo" || rpcErr.Plugin != "RemoteFortressReader" || rpcErr.Code != tt.code || rpcErr.Text != "no world loaded" {
+			t.Errorf("code %d: got %+v", tt.code, *rpcErr)
+		}
+	}
+
+	// A method that can't be bound fails like DFHack's own BindMethod.
+	_, _, err = c.GetWorldInfo()
+	var rpcErr *dfhack.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Method != "GetWorldInfo" || rpcErr.Code != dfhacktest.Failure {
+		t.Errorf("unbound method: got %v", err)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	s := echoServer()
+	defer s.Close()
+
+	var mtx sync.Mutex
+	var states []dfhack.ConnState
+	c, err := s.Dial(&dfhack.Dialer{
+		Reconnect:  true,
+		MinBackoff: time.Millisecond,
+		StateChanged: func(state dfhack.ConnState, err error) {
+			mtx.Lock()
+			states = append(states, state)
+			mtx.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := c.GetVersion(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Disconnect()
+
+	// Calls may fail while the connection is lost, but not for long, and
+	// the methods they use are bound again on the new connection.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, err := runCommand(context.Background(), c, "again")
+		if err == nil && got == "again" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no reconnect: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := c.GetVersion(); err != dfhack.ErrClosed {
+		t.Errorf("call after Close: got %v, want %v", err, dfhack.ErrClosed)
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	want := []dfhack.ConnState{dfhack.StateConnected, dfhack.StateDisconnected, dfhack.StateConnected, dfhack.StateClosed}
+	if fmt.Sprint(states) != fmt.Sprint(want) {
+		t.Errorf("states: got %v, want %v", states, want)
+	}
+}
+
+func TestNoReconnect(t *testing.T) {
+	s := echoServer()
+	defer s.Close()
+
+	c, err := s.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, _, err := c.GetVersion(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Disconnect()
+	time.Sleep(10 * time.Millisecond)
+
+	if _, _, err := c.GetVersion(); err == nil {
+		t.Error("call after disconnect succeeded without Reconnect")
+	}
+}
+
+// unreachable returns a connection that reconnects to a server that has
+// since been closed.
+func unreachable(t *testing.T) *dfhack.Conn {
+	s := echoServer()
+	c, err := s.Dial(&dfhack.Dialer{
+		Reconnect:  true,
+		MinBackoff: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Close()
+
+	// Keep a reconnect going in the background.
+	go func() { _, _, _ = c.GetVersion() }()
+	time.Sleep(50 * time.Millisecond)
+
+	return c
+}
+
+func TestCloseDuringReconnect(t *testing.T) {
+	c := unreachable(t)
+
+	done := make(chan error, 1)
+	go func() { done <- c.Close() }()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked by a reconnect")
+	}
+}
+
+func TestDeadlineDuringReconnect(t *testing.T) {
+	c := unreachable(t)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := c.GetVersionContext(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != context.DeadlineExceeded {
+			t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadline ignored during a reconnect")
+	}
+}
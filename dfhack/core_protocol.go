@@ -1,91 +1,98 @@
 package dfhack
 
 import (
+	"context"
+
 	"github.com/BenLubar/arm_ok/dfhack/dfproto"
 	"github.com/golang/protobuf/proto"
 )
 
 func (c *Conn) RoundTripBind(command string, plugin *string, in, out string, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
-	var id int16
-	var ok bool
-	key := [3]string{command, in, out}
+	return c.RoundTripBindContext(context.Background(), command, plugin, in, out, req, resp)
+}
 
-	c.mtx.Lock()
-	if plugin == nil {
-		id, ok = c.bound[key]
-	} else {
-		id, ok = c.plugin[*plugin][key]
-	}
-	c.mtx.Unlock()
+func (c *Conn) RoundTripBindContext(ctx context.Context, command string, plugin *string, in, out string, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	return c.intercept(ctx, describe(command, plugin, in, out), req, resp, c.invoke)
+}
 
-	if ok {
-		return c.roundTrip(id, req, resp)
-	}
+func (c *Conn) roundTripBind(ctx context.Context, command string, plugin *string, in, out string, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	key := [3]string{command, in, out}
 
-	bind, text, err := c.BindMethod(&dfproto.CoreBindRequest{
-		Method:    &command,
-		Plugin:    plugin,
-		InputMsg:  &in,
-		OutputMsg: &out,
-	})
-	if err != nil {
-		return text, err
-	}
+	for {
+		s, err := c.current(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	id = int16(bind)
+		c.mtx.Lock()
+		id, ok := s.lookup(plugin, key)
+		c.mtx.Unlock()
 
-	c.mtx.Lock()
-	if plugin == nil {
-		c.bound[key] = id
-	} else {
-		if c.plugin[*plugin] == nil {
-			c.plugin[*plugin] = make(map[[3]string]int16)
+		if stats := c.dialer.Stats; stats != nil {
+			var pluginName string
+			if plugin != nil {
+				pluginName = *plugin
+			}
+			stats.HandleBind(command, pluginName, ok)
 		}
-		c.plugin[*plugin][key] = id
-	}
-	c.mtx.Unlock()
 
-	text2, err := c.roundTrip(id, req, resp)
-	// Don't call append if there's a chance both slices are nil.
-	if text == nil {
-		return text2, err
-	}
-	return append(text, text2...), err
-}
+		var text []*dfproto.CoreTextNotification
+		if !ok {
+			var bind dfproto.CoreBindReply
+			// Report failure to bind as a failure of the method
+			// being bound, which is what the caller asked for.
+			text, err = c.roundTripOn(ctx, s, 0, command, plugin, &dfproto.CoreBindRequest{
+				Method:    &command,
+				Plugin:    plugin,
+				InputMsg:  &in,
+				OutputMsg: &out,
+			}, &bind)
+			if err == errStaleStream {
+				continue
+			}
+			if err != nil {
+				return text, err
+			}
 
-// RPC BindMethod : CoreBindRequest -> CoreBindReply
-func (c *Conn) BindMethod(req *dfproto.CoreBindRequest) (int32, []*dfproto.CoreTextNotification, error) {
-	var reply dfproto.CoreBindReply
-	text, err := c.roundTrip(0, req, &reply)
-	return reply.GetAssignedId(), text, err
-}
+			id = int16(bind.GetAssignedId())
 
-// RPC RunCommand : CoreRunCommandRequest -> EmptyMessage
-func (c *Conn) RunCommand(req *dfproto.CoreRunCommandRequest) ([]*dfproto.CoreTextNotification, error) {
-	var reply dfproto.EmptyMessage
-	text, err := c.RoundTripBind("RunCommand", nil, "dfproto.CoreRunCommandRequest", "dfproto.EmptyMessage", req, &reply)
-	return text, err
-}
+			c.mtx.Lock()
+			s.store(plugin, key, id)
+			c.mtx.Unlock()
+		}
 
-// RPC CoreSuspend : EmptyMessage -> IntMessage
-func (c *Conn) CoreSuspend() (int32, []*dfproto.CoreTextNotification, error) {
-	var req dfproto.EmptyMessage
-	var reply dfproto.IntMessage
-	text, err := c.RoundTripBind("CoreSuspend", nil, "dfproto.EmptyMessage", "dfproto.IntMessage", &req, &reply)
-	return reply.GetValue(), text, err
+		text2, err := c.roundTripOn(ctx, s, id, command, plugin, req, resp)
+		if err == errStaleStream {
+			// The socket we bound the method on is gone; bind it
+			// again on the new one.
+			continue
+		}
+		// Don't call append if there's a chance both slices are nil.
+		if text == nil {
+			return text2, err
+		}
+		return append(text, text2...), err
+	}
 }
 
-// RPC CoreResume : EmptyMessage -> IntMessage
-func (c *Conn) CoreResume() (int32, []*dfproto.CoreTextNotification, error) {
-	var req dfproto.EmptyMessage
-	var reply dfproto.IntMessage
-	text, err := c.RoundTripBind("CoreResume", nil, "dfproto.EmptyMessage", "dfproto.IntMessage", &req, &reply)
-	return reply.GetValue(), text, err
+func (s *stream) lookup(plugin *string, key [3]string) (int16, bool) {
+	var id int16
+	var ok bool
+	if plugin == nil {
+		id, ok = s.bound[key]
+	} else {
+		id, ok = s.plugin[*plugin][key]
+	}
+	return id, ok
 }
 
-// RPC RunLua : CoreRunLuaRequest -> StringListMessage
-func (c *Conn) RunLua(req *dfproto.CoreRunLuaRequest) ([]string, []*dfproto.CoreTextNotification, error) {
-	var reply dfproto.StringListMessage
-	text, err := c.RoundTripBind("RunLua", nil, "dfproto.CoreRunLuaRequest", "dfproto.StringListMessage", req, &reply)
-	return reply.GetValue(), text, err
+func (s *stream) store(plugin *string, key [3]string, id int16) {
+	if plugin == nil {
+		s.bound[key] = id
+	} else {
+		if s.plugin[*plugin] == nil {
+			s.plugin[*plugin] = make(map[[3]string]int16)
+		}
+		s.plugin[*plugin][key] = id
+	}
 }
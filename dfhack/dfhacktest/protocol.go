@@ -0,0 +1,86 @@
+package dfhacktest
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	rpcMagicRequest  = [8]byte{'D', 'F', 'H', 'a', 'c', 'k', '?', '\n'}
+	rpcMagicResponse = [8]byte{'D', 'F', 'H', 'a', 'c', 'k', '!', '\n'}
+)
+
+const rpcVersion int32 = 1
+
+type rpcHandshakeHeader struct {
+	Magic   [8]byte
+	Version int32
+}
+
+const maxMessageSize int32 = 8 * 1048576
+
+const (
+	rpcReplyResult int16 = -1
+	rpcReplyFail   int16 = -2
+	rpcReplyText   int16 = -3
+	rpcRequestQuit int16 = -4
+)
+
+type rpcMessageHeader struct {
+	ID   int16
+	Pad  [2]byte
+	Size int32
+}
+
+// padSubscribed is set in the first byte of a result's padding to
+// acknowledge a subscription.
+const padSubscribed byte = 1
+
+func writeMessage(w io.Writer, id int16, pad [2]byte, msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	if err = binary.Write(w, binary.LittleEndian, &rpcMessageHeader{
+		ID:   id,
+		Pad:  pad,
+		Size: int32(len(b)),
+	}); err != nil {
+		return err
+	}
+
+	if len(b) != 0 {
+		_, err = w.Write(b)
+	}
+	return err
+}
+
+func writeReply(w io.Writer, r *Reply) error {
+	for _, text := range r.Text {
+		if err := writeMessage(w, rpcReplyText, [2]byte{}, text); err != nil {
+			return err
+		}
+	}
+
+	if r.Code != 0 {
+		// RPC_REPLY_FAIL uses the size field to hold the error code.
+		return binary.Write(w, binary.LittleEndian, &rpcMessageHeader{
+			ID:   rpcReplyFail,
+			Size: r.Code,
+		})
+	}
+
+	result := r.Result
+	if result == nil {
+		result = &dfproto.EmptyMessage{}
+	}
+	var pad [2]byte
+	if r.Subscribed {
+		pad[0] = padSubscribed
+	}
+	return writeMessage(w, rpcReplyResult, pad, result)
+}
@@ -0,0 +1,416 @@
+// Package dfhacktest provides a fake DFHack server for testing code that
+// uses package dfhack without running Dwarf Fortress.
+//
+// The server speaks the real handshake and message framing. Calls are
+// answered by handlers registered with Handle, Reply, or Fail; methods
+// without a handler can't be bound, just like a method of a plugin that
+// isn't loaded.
+package dfhacktest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+// Command results for Reply.Code, matching DFHack's CR_* values.
+const (
+	LinkFailure    int32 = -3
+	NeedsConsole   int32 = -2
+	NotImplemented int32 = -1
+	Failure        int32 = 1
+	WrongUsage     int32 = 2
+	NotFound       int32 = 3
+)
+
+var ErrServerClosed = errors.New("dfhacktest: server closed")
+
+// ErrNilHandler is returned by HandleMethod when it is given no handler for
+// a method other than BindMethod, which the server answers itself.
+var ErrNilHandler = errors.New("dfhacktest: nil handler")
+
+// A Reply is the server's answer to one call.
+type Reply struct {
+	// Text is sent as notifications before the result.
+	Text []*dfproto.CoreTextNotification
+
+	// Result is the reply message. If it is nil, an empty message is
+	// sent. It is ignored if Code is non-zero.
+	Result proto.Message
+
+	// Code, if non-zero, fails the call with that command result.
+	Code int32
+
+	// Delay holds the reply back, in addition to the server's latency.
+	// Calls after it on the same connection wait too, as they would on
+	// a real server.
+	Delay time.Duration
+
+	// Disconnect closes the connection instead of replying.
+	Disconnect bool
+
+	// Subscribed acknowledges a subscription, as armok_web does. The
+	// server never pushes anything, though.
+	Subscribed bool
+}
+
+// A Handler answers a call. req is of the type returned by the method's
+// NewIn. Handlers for different connections may run concurrently.
+type Handler func(req proto.Message) Reply
+
+// Text returns a text notification containing s, for use in Reply.Text.
+func Text(s string) *dfproto.CoreTextNotification {
+	return &dfproto.CoreTextNotification{
+		Fragments: []*dfproto.CoreTextFragment{
+			{Text: proto.String(s)},
+		},
+	}
+}
+
+type method struct {
+	dfhack.Method
+	handler Handler
+}
+
+type Server struct {
+	mtx     sync.Mutex
+	methods []*method // indexed by assigned id
+	ids     map[[2]string]int16
+	latency time.Duration
+	conns   map[io.ReadWriteCloser]struct{}
+	lns     []net.Listener
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewServer returns a server that only answers BindMethod. RunCommand is
+// reserved id 1, as on a real server, but fails with NotImplemented until
+// it is given a handler.
+func NewServer() *Server {
+	s := &Server{
+		ids:   make(map[[2]string]int16),
+		conns: make(map[io.ReadWriteCloser]struct{}),
+	}
+
+	bind, _ := dfhack.LookupMethod("", "BindMethod")
+	_ = s.HandleMethod(bind, nil) // answered by the server itself
+	s.Fail("", "RunCommand", NotImplemented, "")
+
+	return s
+}
+
+// Handle sets the handler for a method listed in dfhack.Methods. It panics
+// if there is no such method or h is nil.
+func (s *Server) Handle(plugin, name string, h Handler) {
+	m, ok := dfhack.LookupMethod(plugin, name)
+	if !ok {
+		panic("dfhacktest: unknown method " + plugin + "::" + name)
+	}
+	if err := s.HandleMethod(m, h); err != nil {
+		panic(err)
+	}
+}
+
+// HandleMethod sets the handler for m, which need not be listed in
+// dfhack.Methods. A method keeps its id if its handler is replaced.
+func (s *Server) HandleMethod(m dfhack.Method, h Handler) error {
+	if h == nil && (m.Plugin != "" || m.Name != "BindMethod") {
+		return ErrNilHandler
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	key := [2]string{m.Plugin, m.Name}
+	if id, ok := s.ids[key]; ok {
+		s.methods[id] = &method{m, h}
+		return nil
+	}
+
+	s.ids[key] = int16(len(s.methods))
+	s.methods = append(s.methods, &method{m, h})
+	return nil
+}
+
+// Reply makes every call to a method return result.
+func (s *Server) Reply(plugin, name string, result proto.Message) {
+	s.Handle(plugin, name, func(proto.Message) Reply {
+		return Reply{Result: result}
+	})
+}
+
+// Fail makes every call to a method fail with code, after sending text as
+// a notification if it is not empty.
+func (s *Server) Fail(plugin, name string, code int32, text string) {
+	var r Reply
+	r.Code = code
+	if text != "" {
+		r.Text = append(r.Text, Text(text))
+	}
+
+	s.Handle(plugin, name, func(proto.Message) Reply {
+		return r
+	})
+}
+
+// SetLatency delays every reply by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mtx.Lock()
+	s.latency = d
+	s.mtx.Unlock()
+}
+
+// Disconnect closes every open connection. The server keeps accepting new
+// ones.
+func (s *Server) Disconnect() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for sock := range s.conns {
+		_ = sock.Close()
+	}
+}
+
+// Close stops the listeners, closes every connection, and waits for
+// them to finish.
+func (s *Server) Close() error {
+	s.mtx.Lock()
+	if s.closed {
+		s.mtx.Unlock()
+		return ErrServerClosed
+	}
+	s.closed = true
+	for _, ln := range s.lns {
+		_ = ln.Close()
+	}
+	for sock := range s.conns {
+		_ = sock.Close()
+	}
+	s.mtx.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+// Pipe returns the client end of a new in-memory connection to the server.
+func (s *Server) Pipe() (net.Conn, error) {
+	client, server := net.Pipe()
+	if err := s.serve(server); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// DialSocket can be used as a dfhack.Dialer's DialSocket. It ignores addr
+// and returns a new Pipe.
+func (s *Server) DialSocket(ctx context.Context, addr string) (io.ReadWriteCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.Pipe()
+}
+
+// Dial connects to the server with d, which may be nil, replacing its
+// DialSocket.
+func (s *Server) Dial(d *dfhack.Dialer) (*dfhack.Conn, error) {
+	var d1 dfhack.Dialer
+	if d != nil {
+		d1 = *d
+	}
+	d1.DialSocket = s.DialSocket
+	return d1.Dial("dfhacktest")
+}
+
+// Listen starts accepting connections on a loopback port and returns its
+// address, which can be passed to dfhack.Dial.
+func (s *Server) Listen() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		_ = s.Serve(ln)
+	}()
+
+	return ln.Addr().String(), nil
+}
+
+// Serve accepts connections on ln until it fails or the server is closed,
+// answering each in its own goroutine. ln is closed when the server is.
+func (s *Server) Serve(ln net.Listener) error {
+	s.mtx.Lock()
+	if s.closed {
+		s.mtx.Unlock()
+		_ = ln.Close()
+		return ErrServerClosed
+	}
+	s.lns = append(s.lns, ln)
+	s.mtx.Unlock()
+
+	for {
+		sock, err := ln.Accept()
+		if err != nil {
+			s.mtx.Lock()
+			closed := s.closed
+			s.mtx.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+		if err = s.serve(sock); err != nil {
+			_ = sock.Close()
+			return err
+		}
+	}
+}
+
+func (s *Server) serve(sock io.ReadWriteCloser) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.closed {
+		return ErrServerClosed
+	}
+	s.conns[sock] = struct{}{}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		_ = s.ServeConn(sock)
+
+		s.mtx.Lock()
+		delete(s.conns, sock)
+		s.mtx.Unlock()
+	}()
+
+	return nil
+}
+
+// ServeConn performs the handshake on sock and answers calls until the
+// client quits or the connection fails. sock is closed when it returns.
+func (s *Server) ServeConn(sock io.ReadWriteCloser) error {
+	defer sock.Close()
+
+	var hs rpcHandshakeHeader
+	if err := binary.Read(sock, binary.LittleEndian, &hs); err != nil {
+		return err
+	}
+	if hs.Magic != rpcMagicRequest || hs.Version != rpcVersion {
+		return dfhack.ErrInvalidHandshake
+	}
+	if err := binary.Write(sock, binary.LittleEndian, &rpcHandshakeHeader{
+		Magic:   rpcMagicResponse,
+		Version: rpcVersion,
+	}); err != nil {
+		return err
+	}
+
+	for {
+		var header rpcMessageHeader
+		if err := binary.Read(sock, binary.LittleEndian, &header); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if header.ID == rpcRequestQuit {
+			return nil
+		}
+		if header.Size < 0 || header.Size > maxMessageSize {
+			return dfhack.ErrMessageTooLarge
+		}
+
+		b := make([]byte, header.Size)
+		if _, err := io.ReadFull(sock, b); err != nil {
+			return err
+		}
+
+		r := s.call(header.ID, b)
+
+		s.mtx.Lock()
+		delay := s.latency + r.Delay
+		s.mtx.Unlock()
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+
+		if r.Disconnect {
+			return nil
+		}
+		if err := writeReply(sock, &r); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) call(id int16, b []byte) Reply {
+	s.mtx.Lock()
+	var m *method
+	if id >= 0 && int(id) < len(s.methods) {
+		m = s.methods[id]
+	}
+	s.mtx.Unlock()
+
+	if m == nil {
+		return Reply{
+			Text: []*dfproto.CoreTextNotification{Text(fmt.Sprintf("RPC call of invalid id %d\n", id))},
+			Code: NotFound,
+		}
+	}
+
+	req := m.NewIn()
+	if err := proto.Unmarshal(b, req); err != nil {
+		return Reply{Code: LinkFailure}
+	}
+
+	if id == 0 {
+		return s.bind(req.(*dfproto.CoreBindRequest))
+	}
+
+	return m.handler(req)
+}
+
+func (s *Server) bind(req *dfproto.CoreBindRequest) Reply {
+	s.mtx.Lock()
+	id, ok := s.ids[[2]string{req.GetPlugin(), req.GetMethod()}]
+	var m *method
+	if ok {
+		m = s.methods[id]
+	}
+	s.mtx.Unlock()
+
+	if !ok {
+		return Reply{
+			Text: []*dfproto.CoreTextNotification{Text(fmt.Sprintf("RPC method not found: %s::%s\n", req.GetPlugin(), req.GetMethod()))},
+			Code: Failure,
+		}
+	}
+
+	if m.In != req.GetInputMsg() || m.Out != req.GetOutputMsg() {
+		return Reply{
+			Text: []*dfproto.CoreTextNotification{Text(fmt.Sprintf("Requested wrong signature for RPC method: %s::%s\n", req.GetPlugin(), req.GetMethod()))},
+			Code: Failure,
+		}
+	}
+
+	return Reply{
+		Result: &dfproto.CoreBindReply{
+			AssignedId: proto.Int32(int32(id)),
+		},
+	}
+}
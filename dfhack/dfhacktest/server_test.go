@@ -0,0 +1,69 @@
+package dfhacktest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfhacktest"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestServer(t *testing.T) {
+	s := dfhacktest.NewServer()
+	defer s.Close()
+
+	s.Handle("RemoteFortressReader", "GetMapInfo", func(proto.Message) dfhacktest.Reply {
+		return dfhacktest.Reply{
+			Text:   []*dfproto.CoreTextNotification{dfhacktest.Text("loading")},
+			Result: &RemoteFortressReader.MapInfo{SaveName: proto.String("region1")},
+		}
+	})
+
+	addr, err := s.Listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := dfhack.Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	info, text, err := c.GetMapInfo()
+	if err != nil || info.GetSaveName() != "region1" || len(text) != 1 {
+		t.Errorf("GetMapInfo: got %v, %v, %v", info, text, err)
+	}
+
+	if _, err := c.RunCommand(&dfproto.CoreRunCommandRequest{Command: proto.String("help")}); !errors.Is(err, dfhack.ErrNotImplemented) {
+		t.Errorf("RunCommand: got %v, want %v", err, dfhack.ErrNotImplemented)
+	}
+}
+
+func TestHandleMethodNil(t *testing.T) {
+	s := dfhacktest.NewServer()
+	defer s.Close()
+
+	m, _ := dfhack.LookupMethod("", "GetVersion")
+	if err := s.HandleMethod(m, nil); err != dfhacktest.ErrNilHandler {
+		t.Errorf("HandleMethod(GetVersion, nil): got %v, want %v", err, dfhacktest.ErrNilHandler)
+	}
+
+	bind, _ := dfhack.LookupMethod("", "BindMethod")
+	if err := s.HandleMethod(bind, nil); err != nil {
+		t.Errorf("HandleMethod(BindMethod, nil): %v", err)
+	}
+
+	c, err := s.Dial(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// The rejected method was never added, so it can't be bound.
+	if _, _, err := c.GetVersion(); !errors.Is(err, dfhack.ErrFailure) {
+		t.Errorf("GetVersion: got %v, want %v", err, dfhack.ErrFailure)
+	}
+}
@@ -0,0 +1,272 @@
+package dfhack
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+)
+
+// A Dialer contains options for connecting to DFHack. The zero value is
+// equivalent to calling Dial.
+type Dialer struct {
+	// Reconnect makes the connection redial the same address when the
+	// socket is lost, redoing the handshake and rebinding methods as they
+	// are called. Calls made while disconnected wait for the connection
+	// to come back until their context is done. The initial dial is
+	// retried the same way. Close interrupts a reconnect in progress.
+	Reconnect bool
+
+	// MinBackoff and MaxBackoff bound the delay between failed attempts
+	// to reconnect. They default to 100 milliseconds and 30 seconds.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// StateChanged, if non-nil, is called whenever the connection is
+	// established, lost, or closed. err is the cause of the disconnect,
+	// if any. It is called with the connection locked, so it must not
+	// block or call methods on the Conn.
+	StateChanged func(state ConnState, err error)
+
+	// TextHandler, if non-nil, receives the text notifications of every
+	// call that doesn't have its own handler set by WithTextHandler.
+	TextHandler TextHandler
+
+	// DialSocket, if non-nil, is used instead of the package's DialSocket
+	// to open the socket the handshake is performed on. It is called
+	// again for every reconnect.
+	DialSocket func(ctx context.Context, addr string) (io.ReadWriteCloser, error)
+
+	// Stats, if non-nil, is told about every call the connection makes.
+	Stats StatsHandler
+
+	// Interceptors wrap every call made on the connection, the first
+	// being the outermost. They see calls as the caller made them, so
+	// the BindMethod calls made by RoundTripBind are not intercepted.
+	Interceptors []Interceptor
+}
+
+type ConnState int
+
+const (
+	StateConnected ConnState = iota
+	StateDisconnected
+	StateClosed
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	case StateClosed:
+		return "closed"
+	}
+	return "ConnState(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Connect connects to the default address: the DFHACK_PORT on the local
+// machine, or the server the page was loaded from in a browser.
+func Connect() (*Conn, error) {
+	return ConnectContext(context.Background())
+}
+
+func ConnectContext(ctx context.Context) (*Conn, error) {
+	return DialContext(ctx, "")
+}
+
+func Dial(addr string) (*Conn, error) {
+	return DialContext(context.Background(), addr)
+}
+
+// DialContext connects to addr and performs the handshake. The context only
+// applies to establishing the connection.
+func DialContext(ctx context.Context, addr string) (*Conn, error) {
+	var d Dialer
+	return d.DialContext(ctx, addr)
+}
+
+func (d *Dialer) Dial(addr string) (*Conn, error) {
+	return d.DialContext(context.Background(), addr)
+}
+
+// DialContext connects to addr, or the default address if addr is empty.
+func (d *Dialer) DialContext(ctx context.Context, addr string) (*Conn, error) {
+	if addr == "" {
+		addr = defaultAddr()
+	}
+
+	c := &Conn{
+		dialer:      *d,
+		addr:        addr,
+		interceptor: ChainInterceptors(d.Interceptors...),
+	}
+	c.dialCtx, c.cancelDial = context.WithCancel(context.Background())
+
+	if d.Reconnect {
+		if _, err := c.current(ctx); err != nil {
+			c.cancelDial()
+			return nil, err
+		}
+		return c, nil
+	}
+
+	s, err := c.connect(ctx)
+	if err != nil {
+		c.cancelDial()
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.start(s)
+	c.setState(StateConnected, nil)
+
+	return c, nil
+}
+
+func (d *Dialer) minBackoff() time.Duration {
+	if d.MinBackoff > 0 {
+		return d.MinBackoff
+	}
+	return 100 * time.Millisecond
+}
+
+func (d *Dialer) maxBackoff() time.Duration {
+	if d.MaxBackoff > 0 {
+		return d.MaxBackoff
+	}
+	return 30 * time.Second
+}
+
+// connect dials a new socket and performs the handshake.
+func (c *Conn) connect(ctx context.Context) (*stream, error) {
+	dial := c.dialer.DialSocket
+	if dial == nil {
+		dial = DialSocket
+	}
+
+	sock, err := dial(ctx, c.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = handshake(ctx, sock); err != nil {
+		_ = sock.Close()
+		return nil, err
+	}
+
+	return &stream{
+		sock:   sock,
+		bound:  make(map[[3]string]int16),
+		plugin: make(map[string]map[[3]string]int16),
+		wlock:  make(chan struct{}, 1),
+		pushes: make(map[int16]PushHandler),
+
+		pushReady: make(chan struct{}, 1),
+	}, nil
+}
+
+// start makes s the connected stream and starts reading replies from it.
+// The connection must be locked.
+func (c *Conn) start(s *stream) {
+	c.stream = s
+	go c.read(s)
+	go c.deliver(s)
+}
+
+// current returns the connected stream, reconnecting first if the Dialer
+// allows it. Only one goroutine redials at a time; the rest wait for it
+// until their context is done.
+func (c *Conn) current(ctx context.Context) (*stream, error) {
+	for {
+		c.mtx.Lock()
+		if c.closed {
+			c.mtx.Unlock()
+			return nil, ErrClosed
+		}
+		if err := ctx.Err(); err != nil {
+			c.mtx.Unlock()
+			return nil, err
+		}
+		if s := c.stream; s != nil {
+			c.mtx.Unlock()
+			return s, nil
+		}
+		if !c.dialer.Reconnect {
+			c.mtx.Unlock()
+			return nil, ErrBrokenConn
+		}
+
+		if c.redialing == nil {
+			c.redialing = make(chan struct{})
+			go c.redial(c.redialing, time.Until(c.retryAt))
+		}
+		redialing := c.redialing
+		c.mtx.Unlock()
+
+		select {
+		case <-redialing:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// redial makes one attempt to reconnect after waiting for the backoff,
+// then closes done. Close cancels both the wait and the attempt.
+func (c *Conn) redial(done chan struct{}, wait time.Duration) {
+	var s *stream
+	var err error
+	if wait > 0 {
+		t := time.NewTimer(wait)
+		select {
+		case <-c.dialCtx.Done():
+			err = c.dialCtx.Err()
+		case <-t.C:
+		}
+		t.Stop()
+	}
+	if err == nil {
+		s, err = c.connect(c.dialCtx)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.redialing = nil
+	close(done)
+
+	if c.closed {
+		if s != nil {
+			_ = s.sock.Close()
+		}
+		return
+	}
+
+	if err != nil {
+		c.backoff *= 2
+		if min := c.dialer.minBackoff(); c.backoff < min {
+			c.backoff = min
+		}
+		if max := c.dialer.maxBackoff(); c.backoff > max {
+			c.backoff = max
+		}
+		c.retryAt = time.Now().Add(c.backoff)
+
+		c.setState(StateDisconnected, err)
+		return
+	}
+
+	c.backoff = 0
+	c.start(s)
+	c.setState(StateConnected, nil)
+}
+
+func (c *Conn) setState(state ConnState, err error) {
+	if c.dialer.StateChanged != nil {
+		c.dialer.StateChanged(state, err)
+	}
+}
@@ -0,0 +1,333 @@
+// +build ignore
+
+// gen_methods generates methods.go from the RPC methods declared in
+// DFHack's .proto files, either by comments of the form
+//
+//	// RPC Name : InputMessage -> OutputMessage
+//
+// or by service definitions. Methods are core methods unless the file has a
+// "// Plugin: Name" comment. Message names without a package are looked up
+// in the file's own package first, then in the other files.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+var flagOut = flag.String("out", "methods.go", "Go file to generate")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: go run gen_methods.go [-out methods.go] file.proto...\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+const importPrefix = "github.com/BenLubar/arm_ok/dfhack/"
+
+type method struct {
+	Name   string
+	Plugin string
+	In     string
+	Out    string
+}
+
+func (m method) EmptyIn() bool { return m.In == "dfproto.EmptyMessage" }
+
+// ShortIn and ShortOut are the message names as DFHack's RPC comments
+// write them.
+func (m method) ShortIn() string  { return m.In[strings.LastIndex(m.In, ".")+1:] }
+func (m method) ShortOut() string { return m.Out[strings.LastIndex(m.Out, ".")+1:] }
+
+func (m method) PluginVar() string {
+	if m.Plugin == "" {
+		return "nil"
+	}
+	return "&plugin" + m.Plugin
+}
+
+// unwrap describes the replies that are returned as a plain value rather
+// than the message itself.
+var unwrap = map[string]struct{ Type, Expr string }{
+	"dfproto.EmptyMessage":      {"", ""},
+	"dfproto.StringMessage":     {"string", "reply.GetValue()"},
+	"dfproto.IntMessage":        {"int32", "reply.GetValue()"},
+	"dfproto.StringListMessage": {"[]string", "reply.GetValue()"},
+	"dfproto.CoreBindReply":     {"int32", "reply.GetAssignedId()"},
+}
+
+func (m method) ResultType() string {
+	if u, ok := unwrap[m.Out]; ok {
+		return u.Type
+	}
+	return "*" + m.Out
+}
+
+func (m method) Result() string {
+	if u, ok := unwrap[m.Out]; ok {
+		return u.Expr
+	}
+	return "&reply"
+}
+
+var (
+	rpcComment    = regexp.MustCompile(`^//\s*RPC\s+(\w+)\s*:\s*([\w.]+)\s*->\s*([\w.]+)\s*$`)
+	pluginComment = regexp.MustCompile(`^//\s*Plugin:\s*(\w+)\s*$`)
+	packageLine   = regexp.MustCompile(`^package\s+([\w.]+)\s*;`)
+	messageLine   = regexp.MustCompile(`^(?:message|enum)\s+(\w+)\s*\{?`)
+	serviceRPC    = regexp.MustCompile(`^rpc\s+(\w+)\s*\(\s*([\w.]+)\s*\)\s*returns\s*\(\s*([\w.]+)\s*\)`)
+)
+
+// A protoFile is what gen_methods needs from a .proto file.
+type protoFile struct {
+	name     string
+	pkg      string
+	messages map[string]bool
+	methods  []method
+	lines    []int // of methods, for errors
+}
+
+// declares reports whether a comment already declared the method.
+func (pf *protoFile) declares(name string) bool {
+	for _, m := range pf.methods {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parse(filename string) (*protoFile, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pf := &protoFile{
+		name:     filename,
+		messages: make(map[string]bool),
+	}
+	var plugin string
+	depth := 0
+
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+
+		if match := rpcComment.FindStringSubmatch(text); match != nil {
+			pf.methods = append(pf.methods, method{Name: match[1], In: match[2], Out: match[3]})
+			pf.lines = append(pf.lines, line)
+			continue
+		}
+		if match := pluginComment.FindStringSubmatch(text); match != nil {
+			plugin = match[1]
+			continue
+		}
+
+		if i := strings.Index(text, "//"); i != -1 {
+			text = strings.TrimSpace(text[:i])
+		}
+
+		if depth == 0 {
+			if match := packageLine.FindStringSubmatch(text); match != nil {
+				pf.pkg = match[1]
+			}
+			if match := messageLine.FindStringSubmatch(text); match != nil {
+				pf.messages[match[1]] = true
+			}
+		}
+		if match := serviceRPC.FindStringSubmatch(text); match != nil && !pf.declares(match[1]) {
+			pf.methods = append(pf.methods, method{Name: match[1], In: match[2], Out: match[3]})
+			pf.lines = append(pf.lines, line)
+		}
+
+		depth += strings.Count(text, "{") - strings.Count(text, "}")
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if pf.pkg == "" {
+		return nil, fmt.Errorf("%s: no package", filename)
+	}
+	for i := range pf.methods {
+		pf.methods[i].Plugin = plugin
+	}
+
+	return pf, nil
+}
+
+// resolve qualifies the message names of every method with their package.
+func resolve(files []*protoFile) error {
+	qualify := func(pf *protoFile, line int, name string) (string, error) {
+		if strings.Contains(name, ".") {
+			return strings.TrimPrefix(name, "."), nil
+		}
+		if pf.messages[name] {
+			return pf.pkg + "." + name, nil
+		}
+
+		var found []string
+		for _, other := range files {
+			if other.messages[name] {
+				found = append(found, other.pkg+"."+name)
+			}
+		}
+		switch len(found) {
+		case 0:
+			return "", fmt.Errorf("%s:%d: no message named %s", pf.name, line, name)
+		case 1:
+			return found[0], nil
+		}
+		return "", fmt.Errorf("%s:%d: %s is ambiguous: %s", pf.name, line, name, strings.Join(found, ", "))
+	}
+
+	for _, pf := range files {
+		for i := range pf.methods {
+			m := &pf.methods[i]
+			var err error
+			if m.In, err = qualify(pf, pf.lines[i], m.In); err != nil {
+				return err
+			}
+			if m.Out, err = qualify(pf, pf.lines[i], m.Out); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen_methods: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+	}
+
+	var files []*protoFile
+	for _, filename := range flag.Args() {
+		pf, err := parse(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		files = append(files, pf)
+	}
+	if err := resolve(files); err != nil {
+		log.Fatal(err)
+	}
+
+	var methods []method
+	seen := map[[2]string]bool{}
+	for _, pf := range files {
+		for _, m := range pf.methods {
+			key := [2]string{m.Plugin, m.Name}
+			if seen[key] {
+				log.Fatalf("%s: duplicate method %s %s", pf.name, m.Plugin, m.Name)
+			}
+			seen[key] = true
+			methods = append(methods, m)
+		}
+	}
+
+	imports := map[string]bool{"dfproto": true}
+	plugins := map[string]bool{}
+	for _, m := range methods {
+		imports[m.In[:strings.Index(m.In, ".")]] = true
+		imports[m.Out[:strings.Index(m.Out, ".")]] = true
+		if m.Plugin != "" {
+			plugins[m.Plugin] = true
+		}
+	}
+
+	var data struct {
+		Source  string
+		Imports []string
+		Plugins []string
+		Methods []method
+	}
+	data.Source = strings.Join(flag.Args(), ", ")
+	for pkg := range imports {
+		data.Imports = append(data.Imports, importPrefix+pkg)
+	}
+	sort.Strings(data.Imports)
+	for plugin := range plugins {
+		data.Plugins = append(data.Plugins, plugin)
+	}
+	sort.Strings(data.Plugins)
+	data.Methods = methods
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, &data); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, buf.Bytes())
+	}
+
+	if err := ioutil.WriteFile(*flagOut, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by gen_methods.go from {{.Source}}. DO NOT EDIT.
+
+package dfhack
+
+import (
+	"context"
+
+{{range .Imports}}	"{{.}}"
+{{end}}	"github.com/golang/protobuf/proto"
+)
+
+{{range .Plugins}}var plugin{{.}} = "{{.}}"
+{{end}}
+// Methods lists the RPC methods that have wrappers on Conn, in the order
+// they appear in the .proto files.
+var Methods = []Method{
+{{- range .Methods}}
+	{
+		Name:   "{{.Name}}",
+		Plugin: "{{.Plugin}}",
+		In:     "{{.In}}",
+		Out:    "{{.Out}}",
+		NewIn:  func() proto.Message { return new({{.In}}) },
+		NewOut: func() proto.Message { return new({{.Out}}) },
+	},
+{{- end}}
+}
+{{range .Methods}}
+// RPC {{.Name}} : {{.ShortIn}} -> {{.ShortOut}}
+func (c *Conn) {{.Name}}({{if not .EmptyIn}}req *{{.In}}{{end}}) ({{with .ResultType}}{{.}}, {{end}}[]*dfproto.CoreTextNotification, error) {
+	return c.{{.Name}}Context(context.Background(){{if not .EmptyIn}}, req{{end}})
+}
+
+func (c *Conn) {{.Name}}Context(ctx context.Context{{if not .EmptyIn}}, req *{{.In}}{{end}}) ({{with .ResultType}}{{.}}, {{end}}[]*dfproto.CoreTextNotification, error) {
+{{- if .EmptyIn}}
+	var req dfproto.EmptyMessage
+{{- end}}
+	var reply {{.Out}}
+{{- if and (eq .Name "BindMethod") (eq .Plugin "")}}
+	text, err := c.roundTrip(ctx, 0, "BindMethod", nil, req, &reply)
+{{- else}}
+	text, err := c.RoundTripBindContext(ctx, "{{.Name}}", {{.PluginVar}}, "{{.In}}", "{{.Out}}", {{if .EmptyIn}}&{{end}}req, &reply)
+{{- end}}
+	return {{with .Result}}{{.}}, {{end}}text, err
+}
+{{end}}`))
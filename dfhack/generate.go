@@ -3,5 +3,7 @@
 //go:generate go get github.com/golang/protobuf/protoc-gen-go
 //go:generate go generate ./RemoteFortressReader
 //go:generate go generate ./dfproto
+//go:generate go generate ./AdventureControl
+//go:generate go run gen_methods.go dfproto/CoreProtocol.proto dfproto/BasicApi.proto RemoteFortressReader/RemoteFortressReader.proto AdventureControl/AdventureControl.proto
 
 package dfhack
@@ -0,0 +1,84 @@
+package dfhack
+
+import (
+	"context"
+
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+// An Invoker makes a call. It binds the method first if needed.
+type Invoker func(ctx context.Context, m Method, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error)
+
+// An Interceptor wraps the calls made on a Conn, for logging, tracing,
+// retries, caching, or fault injection. It may change req before passing it
+// on, call invoke more than once or not at all, and change the response,
+// text, and error it returns.
+//
+// m describes the method being called. NewIn and NewOut are only set for
+// methods in Methods. invoke may be passed a different method, for example
+// to look something up before making the call.
+type Interceptor func(ctx context.Context, m Method, req, resp proto.Message, invoke Invoker) ([]*dfproto.CoreTextNotification, error)
+
+// ChainInterceptors returns an Interceptor that runs each of interceptors
+// in turn, the first being the outermost.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	switch len(interceptors) {
+	case 0:
+		return nil
+	case 1:
+		return interceptors[0]
+	}
+
+	return func(ctx context.Context, m Method, req, resp proto.Message, invoke Invoker) ([]*dfproto.CoreTextNotification, error) {
+		return interceptors[0](ctx, m, req, resp, chainInvoker(interceptors[1:], invoke))
+	}
+}
+
+func chainInvoker(interceptors []Interceptor, invoke Invoker) Invoker {
+	if len(interceptors) == 0 {
+		return invoke
+	}
+
+	return func(ctx context.Context, m Method, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+		return interceptors[0](ctx, m, req, resp, chainInvoker(interceptors[1:], invoke))
+	}
+}
+
+// intercept makes a call through the Dialer's interceptors.
+func (c *Conn) intercept(ctx context.Context, m Method, req, resp proto.Message, invoke Invoker) ([]*dfproto.CoreTextNotification, error) {
+	if c.interceptor == nil {
+		return invoke(ctx, m, req, resp)
+	}
+	return c.interceptor(ctx, m, req, resp, invoke)
+}
+
+// invoke is the Invoker at the end of the chain. It binds and calls m.
+func (c *Conn) invoke(ctx context.Context, m Method, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	var plugin *string
+	if m.Plugin != "" {
+		plugin = &m.Plugin
+	}
+	return c.roundTripBind(ctx, m.Name, plugin, m.In, m.Out, req, resp)
+}
+
+// describe returns the Method for a call by name, filling in the rest from
+// Methods if it is known.
+func describe(method string, plugin *string, in, out string) Method {
+	var pluginName string
+	if plugin != nil {
+		pluginName = *plugin
+	}
+
+	m, ok := LookupMethod(pluginName, method)
+	if ok && (in == "" || m.In == in) && (out == "" || m.Out == out) {
+		return m
+	}
+
+	return Method{
+		Name:   method,
+		Plugin: pluginName,
+		In:     in,
+		Out:    out,
+	}
+}
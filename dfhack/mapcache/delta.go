@@ -0,0 +1,97 @@
+package mapcache
+
+import (
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+)
+
+// Hashes records the hash of every block a client has been sent, so that
+// only blocks that changed are sent again. A nil Hashes is a client that
+// hasn't called ResetMapHashes yet.
+type Hashes map[Pos]uint32
+
+// Delta returns the blocks within req that differ from hashes, and records
+// them in hashes as sent. Like RemoteFortressReader, it goes down from the
+// top of the requested area and spirals out from the center of each level,
+// stopping after req.BlocksNeeded blocks if it is set. skipped is the
+// number of blocks left out because the client already had them.
+//
+// MapX and MapY of the returned list are not set.
+func (c *Cache) Delta(req *RemoteFortressReader.BlockRequest, hashes Hashes) (list *RemoteFortressReader.BlockList, skipped int) {
+	list = &RemoteFortressReader.BlockList{}
+
+	// Whether the blocks get sent before ResetMapHashes is undefined. We
+	// take the easy route of sending nothing.
+	if hashes == nil {
+		return list, 0
+	}
+
+	if req.BlocksNeeded != nil && req.GetBlocksNeeded() <= 0 {
+		return list, 0
+	}
+
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	Spiral(req, func(pos Pos) bool {
+		block, ok := c.blocks[pos]
+		if !ok {
+			return true
+		}
+		if hash, ok := hashes[pos]; ok && hash == block.Hash {
+			skipped++
+			return true
+		}
+
+		list.MapBlocks = append(list.MapBlocks, block.Block)
+		hashes[pos] = block.Hash
+
+		return req.BlocksNeeded == nil || int32(len(list.MapBlocks)) < req.GetBlocksNeeded()
+	})
+
+	return list, skipped
+}
+
+// Spiral calls fn for every position within req in the order
+// RemoteFortressReader sends blocks, until fn returns false.
+func Spiral(req *RemoteFortressReader.BlockRequest, fn func(pos Pos) bool) {
+	min_x, max_x := req.GetMinX(), req.GetMaxX()
+	min_y, max_y := req.GetMinY(), req.GetMaxY()
+	min_z, max_z := req.GetMinZ(), req.GetMaxZ()
+	center_x := (min_x + max_x) / 2
+	center_y := (min_y + max_y) / 2
+	number_of_points := ((max_x - center_x + 1) * 2) * ((max_y - center_y + 1) * 2)
+
+	for zz := max_z - 1; zz >= min_z; zz-- {
+		// (di, dj) is a vector - direction in which we move right now
+		var di, dj int32 = 1, 0
+		// length of current segment
+		var segment_length, segment_passed int32 = 1, 0
+		// current position (i, j) and how much of current segment we passed
+		var i, j, k int32 = center_x, center_y, 0
+		for k = 0; k < number_of_points; k++ {
+			if i >= min_x && i < max_x && j >= min_y && j < max_y {
+				if !fn(Pos{i, j, zz}) {
+					return
+				}
+			}
+
+			// make a step, add 'direction' vector (di, dj) to current position (i, j)
+			i += di
+			j += dj
+			segment_passed++
+
+			if segment_passed == segment_length {
+				// done with current segment
+				segment_passed = 0
+
+				// 'rotate' directions
+				di, dj = -dj, di
+
+				// increase segment length if necessary
+				if dj == 0 {
+					segment_length++
+				}
+			}
+		}
+	}
+}
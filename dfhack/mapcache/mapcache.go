@@ -0,0 +1,215 @@
+// Package mapcache stores the map blocks sent by RemoteFortressReader.
+//
+// GetBlockList only sends the parts of a block that changed since the last
+// call on the same connection, so a block has to be merged with what is
+// already known about it. A Cache does that, hashes each merged block, and
+// can produce the blocks a client with older hashes needs, the same way
+// RemoteFortressReader does.
+package mapcache
+
+import (
+	"hash/adler32"
+	"sync"
+
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/golang/protobuf/proto"
+)
+
+// A Pos is the position of a block: x and y are in blocks (16 tiles) and z
+// is in tiles.
+type Pos [3]int32
+
+// PosOf returns the position of b.
+func PosOf(b *RemoteFortressReader.MapBlock) Pos {
+	return Pos{b.GetMapX() / 16, b.GetMapY() / 16, b.GetMapZ()}
+}
+
+// A Block is the merged state of one map block. Block must not be
+// modified; Apply replaces it rather than changing it.
+type Block struct {
+	Block *RemoteFortressReader.MapBlock
+	Hash  uint32
+}
+
+// A Watcher is told about every block that changes, and is called with a
+// zero Block for every block removed by Reset. It is called after the Cache
+// is updated, from the goroutine that changed it, so it may read the Cache
+// but must not block for long.
+type Watcher func(pos Pos, b Block)
+
+type Cache struct {
+	mtx      sync.RWMutex
+	blocks   map[Pos]Block
+	watchers map[*Watcher]struct{}
+}
+
+func New() *Cache {
+	return &Cache{
+		blocks:   make(map[Pos]Block),
+		watchers: make(map[*Watcher]struct{}),
+	}
+}
+
+// Watch calls w for every change to the cache until the returned function
+// is called.
+func (c *Cache) Watch(w Watcher) (stop func()) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := &w
+	c.watchers[key] = struct{}{}
+
+	return func() {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		delete(c.watchers, key)
+	}
+}
+
+func (c *Cache) notify(changed []Pos, blocks []Block) {
+	if len(changed) == 0 {
+		return
+	}
+
+	c.mtx.RLock()
+	watchers := make([]Watcher, 0, len(c.watchers))
+	for w := range c.watchers {
+		watchers = append(watchers, *w)
+	}
+	c.mtx.RUnlock()
+
+	for _, w := range watchers {
+		for i, pos := range changed {
+			w(pos, blocks[i])
+		}
+	}
+}
+
+// Get returns the block at pos, if it is known.
+func (c *Cache) Get(pos Pos) (Block, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	b, ok := c.blocks[pos]
+	return b, ok
+}
+
+// Len returns the number of blocks in the cache.
+func (c *Cache) Len() int {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return len(c.blocks)
+}
+
+// Range calls fn for each block in the cache, in no particular order, until
+// it returns false. The cache must not be changed from fn.
+func (c *Cache) Range(fn func(pos Pos, b Block) bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	for pos, b := range c.blocks {
+		if !fn(pos, b) {
+			return
+		}
+	}
+}
+
+// Reset removes every block, as is needed after reconnecting to DFHack.
+func (c *Cache) Reset() {
+	c.mtx.Lock()
+	old := c.blocks
+	c.blocks = make(map[Pos]Block)
+	c.mtx.Unlock()
+
+	changed := make([]Pos, 0, len(old))
+	for pos := range old {
+		changed = append(changed, pos)
+	}
+	c.notify(changed, make([]Block, len(changed)))
+}
+
+// Apply merges a block from GetBlockList into the cache and returns the
+// merged block. A field that is empty in b keeps its previous contents. b
+// must not be modified afterwards.
+func (c *Cache) Apply(b *RemoteFortressReader.MapBlock) (Block, bool) {
+	changed, blocks := c.apply([]*RemoteFortressReader.MapBlock{b})
+	c.notify(changed, blocks)
+
+	merged, _ := c.Get(PosOf(b))
+	return merged, len(changed) != 0
+}
+
+// ApplyList applies every block in list and returns the positions of the
+// blocks that changed.
+func (c *Cache) ApplyList(list *RemoteFortressReader.BlockList) []Pos {
+	changed, blocks := c.apply(list.GetMapBlocks())
+	c.notify(changed, blocks)
+	return changed
+}
+
+func (c *Cache) apply(list []*RemoteFortressReader.MapBlock) ([]Pos, []Block) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var changed []Pos
+	var blocks []Block
+
+	for _, b := range list {
+		pos := PosOf(b)
+		old, ok := c.blocks[pos]
+		if ok {
+			b = merge(old.Block, b)
+		}
+
+		data, err := proto.Marshal(b)
+		if err != nil {
+			// should never happen
+			panic(err)
+		}
+
+		block := Block{
+			Block: b,
+			Hash:  adler32.Checksum(data),
+		}
+		if ok && old.Hash == block.Hash {
+			continue
+		}
+
+		c.blocks[pos] = block
+		changed = append(changed, pos)
+		blocks = append(blocks, block)
+	}
+
+	return changed, blocks
+}
+
+// merge returns a copy of b with its empty fields filled in from old.
+func merge(old, b *RemoteFortressReader.MapBlock) *RemoteFortressReader.MapBlock {
+	merged := proto.Clone(b).(*RemoteFortressReader.MapBlock)
+
+	if len(merged.Tiles) == 0 {
+		merged.Tiles = old.Tiles
+	}
+	if len(merged.Materials) == 0 {
+		merged.Materials = old.Materials
+	}
+	if len(merged.LayerMaterials) == 0 {
+		merged.LayerMaterials = old.LayerMaterials
+	}
+	if len(merged.VeinMaterials) == 0 {
+		merged.VeinMaterials = old.VeinMaterials
+	}
+	if len(merged.BaseMaterials) == 0 {
+		merged.BaseMaterials = old.BaseMaterials
+	}
+	if len(merged.Magma) == 0 {
+		merged.Magma = old.Magma
+	}
+	if len(merged.Water) == 0 {
+		merged.Water = old.Water
+	}
+
+	return merged
+}
@@ -0,0 +1,207 @@
+package mapcache
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/golang/protobuf/proto"
+)
+
+func block(x, y, z int32, tiles, water []int32) *RemoteFortressReader.MapBlock {
+	return &RemoteFortressReader.MapBlock{
+		MapX:  proto.Int32(x * 16),
+		MapY:  proto.Int32(y * 16),
+		MapZ:  proto.Int32(z),
+		Tiles: tiles,
+		Water: water,
+	}
+}
+
+func request(minX, maxX, minY, maxY, minZ, maxZ int32) *RemoteFortressReader.BlockRequest {
+	return &RemoteFortressReader.BlockRequest{
+		MinX: proto.Int32(minX), MaxX: proto.Int32(maxX),
+		MinY: proto.Int32(minY), MaxY: proto.Int32(maxY),
+		MinZ: proto.Int32(minZ), MaxZ: proto.Int32(maxZ),
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		old, b    *RemoteFortressReader.MapBlock
+		wantTiles []int32
+		wantWater []int32
+	}{
+		{"empty update", block(1, 1, 1, []int32{1}, []int32{2}), block(1, 1, 1, nil, nil), []int32{1}, []int32{2}},
+		{"tiles only", block(1, 1, 1, []int32{1}, []int32{2}), block(1, 1, 1, []int32{3}, nil), []int32{3}, []int32{2}},
+		{"water only", block(1, 1, 1, []int32{1}, []int32{2}), block(1, 1, 1, nil, []int32{4}), []int32{1}, []int32{4}},
+		{"everything", block(1, 1, 1, []int32{1}, []int32{2}), block(1, 1, 1, []int32{3}, []int32{4}), []int32{3}, []int32{4}},
+		{"nothing known", block(1, 1, 1, nil, nil), block(1, 1, 1, []int32{3}, nil), []int32{3}, nil},
+	}
+
+	for _, tt := range tests {
+		before := proto.Clone(tt.b)
+
+		merged := merge(tt.old, tt.b)
+		if !reflect.DeepEqual(merged.Tiles, tt.wantTiles) || !reflect.DeepEqual(merged.Water, tt.wantWater) {
+			t.Errorf("%s: got tiles %v, water %v; want %v, %v", tt.name, merged.Tiles, merged.Water, tt.wantTiles, tt.wantWater)
+		}
+		if !proto.Equal(tt.b, before) {
+			t.Errorf("%s: merge modified the update", tt.name)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       *RemoteFortressReader.MapBlock
+		changed bool
+	}{
+		{"new block", block(1, 1, 1, []int32{1, 2}, []int32{3}), true},
+		{"same block", block(1, 1, 1, []int32{1, 2}, []int32{3}), false},
+		{"nothing sent", block(1, 1, 1, nil, nil), false},
+		{"same water", block(1, 1, 1, nil, []int32{3}), false},
+		{"new water", block(1, 1, 1, nil, []int32{4}), true},
+		{"new position", block(2, 1, 1, []int32{1, 2}, []int32{4}), true},
+	}
+
+	c := New()
+	var watched []Pos
+	stop := c.Watch(func(pos Pos, b Block) {
+		watched = append(watched, pos)
+	})
+	defer stop()
+
+	for _, tt := range tests {
+		watched = watched[:0]
+
+		b, changed := c.Apply(tt.b)
+		if changed != tt.changed {
+			t.Errorf("%s: changed = %v, want %v", tt.name, changed, tt.changed)
+		}
+		if b.Block == nil || PosOf(b.Block) != PosOf(tt.b) {
+			t.Errorf("%s: got block %v", tt.name, b.Block)
+		}
+		if tt.changed && (len(watched) != 1 || watched[0] != PosOf(tt.b)) {
+			t.Errorf("%s: watcher saw %v", tt.name, watched)
+		} else if !tt.changed && len(watched) != 0 {
+			t.Errorf("%s: watcher saw %v for an unchanged block", tt.name, watched)
+		}
+	}
+
+	if b, _ := c.Get(Pos{1, 1, 1}); !reflect.DeepEqual(b.Block.Tiles, []int32{1, 2}) || !reflect.DeepEqual(b.Block.Water, []int32{4}) {
+		t.Errorf("merged block: %v", b.Block)
+	}
+
+	watched = watched[:0]
+	c.Reset()
+	if c.Len() != 0 || len(watched) != 2 {
+		t.Errorf("after Reset: %d blocks, watcher saw %v", c.Len(), watched)
+	}
+}
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *RemoteFortressReader.MapBlock
+		same bool
+	}{
+		{"same contents", block(1, 1, 1, []int32{1}, nil), block(1, 1, 1, []int32{1}, nil), true},
+		{"different tiles", block(1, 1, 1, []int32{1}, nil), block(1, 1, 1, []int32{2}, nil), false},
+		{"different water", block(1, 1, 1, []int32{1}, []int32{0}), block(1, 1, 1, []int32{1}, []int32{7}), false},
+		{"different position", block(1, 1, 1, []int32{1}, nil), block(1, 1, 2, []int32{1}, nil), false},
+	}
+
+	for _, tt := range tests {
+		a, _ := New().Apply(tt.a)
+		b, _ := New().Apply(tt.b)
+		if same := a.Hash == b.Hash; same != tt.same {
+			t.Errorf("%s: hashes %08x and %08x, want same = %v", tt.name, a.Hash, b.Hash, tt.same)
+		}
+	}
+}
+
+func TestSpiral(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *RemoteFortressReader.BlockRequest
+		want []Pos
+	}{
+		{"one block", request(5, 6, 5, 6, 3, 4), []Pos{{5, 5, 3}}},
+		{"empty", request(5, 5, 5, 6, 3, 4), nil},
+		{"three by three", request(0, 3, 0, 3, 0, 1), []Pos{
+			{1, 1, 0}, {2, 1, 0}, {2, 2, 0}, {1, 2, 0}, {0, 2, 0}, {0, 1, 0}, {0, 0, 0}, {1, 0, 0}, {2, 0, 0},
+		}},
+		{"top level first", request(0, 2, 0, 1, 0, 2), []Pos{
+			{1, 0, 1}, {0, 0, 1}, {1, 0, 0}, {0, 0, 0},
+		}},
+	}
+
+	for _, tt := range tests {
+		var got []Pos
+		Spiral(tt.req, func(pos Pos) bool {
+			got = append(got, pos)
+			return true
+		})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	var n int
+	Spiral(request(0, 3, 0, 3, 0, 3), func(Pos) bool {
+		n++
+		return n < 4
+	})
+	if n != 4 {
+		t.Errorf("Spiral kept going after fn returned false: %d calls", n)
+	}
+}
+
+func TestDelta(t *testing.T) {
+	c := New()
+	c.ApplyList(&RemoteFortressReader.BlockList{MapBlocks: []*RemoteFortressReader.MapBlock{
+		block(1, 1, 5, []int32{1}, nil),
+		block(2, 1, 5, []int32{2}, nil),
+		block(1, 1, 4, []int32{3}, nil),
+		block(9, 9, 5, []int32{4}, nil), // outside the request
+	}})
+
+	req := request(0, 4, 0, 4, 0, 6)
+	limited := request(0, 4, 0, 4, 0, 6)
+	limited.BlocksNeeded = proto.Int32(2)
+
+	h := make(Hashes)
+	tests := []struct {
+		name    string
+		req     *RemoteFortressReader.BlockRequest
+		hashes  Hashes
+		change  *RemoteFortressReader.MapBlock
+		want    []Pos
+		skipped int
+	}{
+		{"before ResetMapHashes", req, nil, nil, nil, 0},
+		{"limited", limited, h, nil, []Pos{{1, 1, 5}, {2, 1, 5}}, 0},
+		{"rest", limited, h, nil, []Pos{{1, 1, 4}}, 2},
+		{"nothing new", req, h, nil, nil, 3},
+		{"one changed", req, h, block(2, 1, 5, []int32{5}, nil), []Pos{{2, 1, 5}}, 2},
+		{"new client", req, make(Hashes), nil, []Pos{{1, 1, 5}, {2, 1, 5}, {1, 1, 4}}, 0},
+	}
+
+	for _, tt := range tests {
+		if tt.change != nil {
+			c.Apply(tt.change)
+		}
+
+		list, skipped := c.Delta(tt.req, tt.hashes)
+		var got []Pos
+		for _, b := range list.MapBlocks {
+			got = append(got, PosOf(b))
+		}
+		if !reflect.DeepEqual(got, tt.want) || skipped != tt.skipped {
+			t.Errorf("%s: got %v, %d skipped; want %v, %d skipped", tt.name, got, skipped, tt.want, tt.skipped)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package dfhack
+
+import (
+	"context"
+
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+// A Method describes an RPC method by the names used to bind it.
+type Method struct {
+	Name   string
+	Plugin string // empty for core methods
+
+	// In and Out are the package-qualified names of the request and
+	// reply messages, such as "dfproto.EmptyMessage".
+	In  string
+	Out string
+
+	// NewIn and NewOut return new, empty request and reply messages.
+	NewIn  func() proto.Message
+	NewOut func() proto.Message
+}
+
+// LookupMethod returns the entry in Methods with the given name and plugin.
+func LookupMethod(plugin, name string) (Method, bool) {
+	for _, m := range Methods {
+		if m.Plugin == plugin && m.Name == name {
+			return m, true
+		}
+	}
+	return Method{}, false
+}
+
+// Call binds and calls m. req and resp must be of the types returned by
+// m.NewIn and m.NewOut.
+func (c *Conn) Call(ctx context.Context, m Method, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	var plugin *string
+	if m.Plugin != "" {
+		plugin = &m.Plugin
+	}
+	return c.RoundTripBindContext(ctx, m.Name, plugin, m.In, m.Out, req, resp)
+}
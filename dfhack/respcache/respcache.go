@@ -0,0 +1,340 @@
+// Package respcache caches the replies to methods whose results only change
+// when a different world is loaded, such as the material and tiletype
+// lists.
+//
+// A Cache is used as a dfhack.Interceptor. The world is identified by the
+// SaveName from RemoteFortressReader's GetMapInfo, which is checked at most
+// once every CheckInterval; replies are only cached while a world is loaded.
+// A cache saved to a file is written at most once every SaveDelay.
+package respcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+// DefaultMethods are the methods cached by New, as {plugin, method}.
+var DefaultMethods = [][2]string{
+	{"", "ListEnums"},
+	{"", "ListJobSkills"},
+	{"RemoteFortressReader", "GetMaterialList"},
+	{"RemoteFortressReader", "GetTiletypeList"},
+	{"RemoteFortressReader", "GetGrowthList"},
+	{"RemoteFortressReader", "GetItemList"},
+	{"RemoteFortressReader", "GetBuildingDefList"},
+	{"RemoteFortressReader", "GetCreatureRaws"},
+	{"RemoteFortressReader", "GetPlantRaws"},
+}
+
+type Cache struct {
+	// CheckInterval is how often the loaded world is checked. Replies
+	// from a world loaded less than this long ago may be from the
+	// previous one. It defaults to 10 seconds.
+	CheckInterval time.Duration
+
+	// Path, if set, is a file the cache is saved to when it changes.
+	// Open loads it.
+	Path string
+
+	// SaveDelay is how long after a change the cache is saved to Path,
+	// so that the replies cached as a client starts up are written
+	// together. It defaults to 5 seconds. Flush saves it sooner.
+	SaveDelay time.Duration
+
+	methods map[[2]string]bool
+
+	mtx     sync.Mutex
+	world   string // the world the entries are from
+	loaded  bool   // whether world was loaded when last checked
+	checked time.Time
+	entries map[key]*entry
+	save    *time.Timer // set while there are changes to save
+
+	saveMtx sync.Mutex // held while writing to Path
+}
+
+type key struct {
+	Plugin  string
+	Method  string
+	Request string
+}
+
+type entry struct {
+	Text   [][]byte
+	Result []byte
+}
+
+// New returns an empty cache for methods, or DefaultMethods if there are
+// none.
+func New(methods ...[2]string) *Cache {
+	if len(methods) == 0 {
+		methods = DefaultMethods
+	}
+
+	c := &Cache{
+		methods: make(map[[2]string]bool),
+		entries: make(map[key]*entry),
+	}
+	for _, m := range methods {
+		c.methods[m] = true
+	}
+	return c
+}
+
+// Open returns a cache like New that is saved to path. If path exists, the
+// cache starts with its contents; they are used once the same world is
+// found to be loaded.
+func Open(path string, methods ...[2]string) (*Cache, error) {
+	c := New(methods...)
+	c.Path = path
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var saved savedCache
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&saved); err != nil {
+		return nil, err
+	}
+
+	c.world = saved.World
+	for _, e := range saved.Entries {
+		c.entries[e.Key] = &entry{Text: e.Text, Result: e.Result}
+	}
+
+	return c, nil
+}
+
+type savedCache struct {
+	World   string
+	Entries []savedEntry
+}
+
+type savedEntry struct {
+	Key    key
+	Text   [][]byte
+	Result []byte
+}
+
+// Invalidate removes every cached reply.
+func (c *Cache) Invalidate() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.world = ""
+	c.loaded = false
+	c.checked = time.Time{}
+	c.entries = make(map[key]*entry)
+	c.changedLocked()
+}
+
+// Len returns the number of cached replies.
+func (c *Cache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.entries)
+}
+
+func (c *Cache) checkInterval() time.Duration {
+	if c.CheckInterval > 0 {
+		return c.CheckInterval
+	}
+	return 10 * time.Second
+}
+
+func (c *Cache) saveDelay() time.Duration {
+	if c.SaveDelay > 0 {
+		return c.SaveDelay
+	}
+	return 5 * time.Second
+}
+
+// Intercept is a dfhack.Interceptor that answers calls from the cache.
+func (c *Cache) Intercept(ctx context.Context, m dfhack.Method, req, resp proto.Message, invoke dfhack.Invoker) ([]*dfproto.CoreTextNotification, error) {
+	if !c.methods[[2]string{m.Plugin, m.Name}] {
+		return invoke(ctx, m, req, resp)
+	}
+
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	k := key{Plugin: m.Plugin, Method: m.Name, Request: string(b)}
+
+	world, err := c.currentWorld(ctx, invoke)
+	if err != nil {
+		return nil, err
+	}
+	if world == "" {
+		return invoke(ctx, m, req, resp)
+	}
+
+	c.mtx.Lock()
+	e, ok := c.entries[k]
+	c.mtx.Unlock()
+
+	if ok {
+		text := make([]*dfproto.CoreTextNotification, len(e.Text))
+		for i, t := range e.Text {
+			text[i] = &dfproto.CoreTextNotification{}
+			if err := proto.Unmarshal(t, text[i]); err != nil {
+				return nil, err
+			}
+		}
+		if len(text) == 0 {
+			text = nil
+		}
+		return text, proto.Unmarshal(e.Result, resp)
+	}
+
+	text, err := invoke(ctx, m, req, resp)
+	if err != nil {
+		return text, err
+	}
+
+	e = &entry{}
+	if e.Result, err = proto.Marshal(resp); err != nil {
+		return text, err
+	}
+	for _, t := range text {
+		tb, err := proto.Marshal(t)
+		if err != nil {
+			return text, err
+		}
+		e.Text = append(e.Text, tb)
+	}
+
+	c.mtx.Lock()
+	if c.world == world {
+		c.entries[k] = e
+		c.changedLocked()
+	}
+	c.mtx.Unlock()
+
+	return text, nil
+}
+
+var getMapInfo, _ = dfhack.LookupMethod("RemoteFortressReader", "GetMapInfo")
+
+// currentWorld returns the name of the loaded world, or "" if none is,
+// checking it if it hasn't been checked recently. Loading a different world
+// invalidates the cache. If the world can't be checked, the cache is left
+// alone and the error is returned.
+func (c *Cache) currentWorld(ctx context.Context, invoke dfhack.Invoker) (string, error) {
+	c.mtx.Lock()
+	if !c.checked.IsZero() && time.Since(c.checked) < c.checkInterval() {
+		world := c.currentLocked()
+		c.mtx.Unlock()
+		return world, nil
+	}
+	c.mtx.Unlock()
+
+	var info RemoteFortressReader.MapInfo
+	_, err := invoke(ctx, getMapInfo, &dfproto.EmptyMessage{}, &info)
+	if err != nil && !errors.Is(err, dfhack.ErrNotFound) {
+		return "", err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// GetMapInfo fails with CR_NOT_FOUND when no world is loaded. The
+	// entries are kept in case the same world is loaded again.
+	world := info.GetSaveName()
+	if err == nil && world != "" {
+		if world != c.world {
+			c.entries = make(map[key]*entry)
+			c.world = world
+			c.changedLocked()
+		}
+		c.loaded = true
+	} else {
+		c.loaded = false
+	}
+	c.checked = time.Now()
+
+	return c.currentLocked(), nil
+}
+
+// currentLocked returns the loaded world as of the last check. The cache
+// must be locked.
+func (c *Cache) currentLocked() string {
+	if !c.loaded {
+		return ""
+	}
+	return c.world
+}
+
+// changedLocked arranges for the cache to be saved to Path, if it is set.
+// The cache must be locked.
+func (c *Cache) changedLocked() {
+	if c.Path == "" || c.save != nil {
+		return
+	}
+
+	c.save = time.AfterFunc(c.saveDelay(), func() {
+		// Errors are ignored; the file is only an optimization.
+		_ = c.Flush()
+	})
+}
+
+// Flush saves the cache to Path now if it has changed since it was last
+// saved.
+func (c *Cache) Flush() error {
+	c.saveMtx.Lock()
+	defer c.saveMtx.Unlock()
+
+	c.mtx.Lock()
+	if c.save == nil {
+		c.mtx.Unlock()
+		return nil
+	}
+	c.save.Stop()
+	c.save = nil
+
+	saved := savedCache{World: c.world}
+	for k, e := range c.entries {
+		saved.Entries = append(saved.Entries, savedEntry{Key: k, Text: e.Text, Result: e.Result})
+	}
+	c.mtx.Unlock()
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&saved); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a
+	// truncated cache behind.
+	f, err := ioutil.TempFile(filepath.Dir(c.Path), ".tmp."+filepath.Base(c.Path)+"-")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(buf.Bytes())
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.Path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,160 @@
+package respcache
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BenLubar/arm_ok/dfhack"
+	"github.com/BenLubar/arm_ok/dfhack/RemoteFortressReader"
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+var listEnums, _ = dfhack.LookupMethod("", "ListEnums")
+
+// fakeDFHack answers GetMapInfo with world, or fails it with err, and
+// counts the other calls.
+type fakeDFHack struct {
+	world string
+	err   error
+	calls int
+}
+
+func (f *fakeDFHack) invoke(ctx context.Context, m dfhack.Method, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	if m.Name == "GetMapInfo" {
+		if f.err != nil {
+			return nil, f.err
+		}
+		resp.(*RemoteFortressReader.MapInfo).SaveName = proto.String(f.world)
+		return nil, nil
+	}
+
+	f.calls++
+	return nil, nil
+}
+
+func TestCurrentWorld(t *testing.T) {
+	errBroken := errors.New("connection broken")
+	notFound := &dfhack.RPCError{Plugin: "RemoteFortressReader", Method: "GetMapInfo", Code: 3}
+	failure := &dfhack.RPCError{Plugin: "RemoteFortressReader", Method: "GetMapInfo", Code: 1}
+
+	tests := []struct {
+		name    string
+		world   string
+		err     error
+		wantErr error
+		cached  bool
+		len     int
+	}{
+		{"first call", "region1", nil, nil, false, 1},
+		{"same world", "region1", nil, nil, true, 1},
+		{"no world loaded", "", notFound, nil, false, 1},
+		{"empty SaveName", "", nil, nil, false, 1},
+		{"GetMapInfo failed", "", failure, dfhack.ErrFailure, false, 1},
+		{"DFHack unreachable", "", errBroken, errBroken, false, 1},
+		{"same world loaded again", "region1", nil, nil, true, 1},
+		{"different world", "region2", nil, nil, false, 1},
+		{"different world cached", "region2", nil, nil, true, 1},
+	}
+
+	c := New()
+	c.CheckInterval = time.Nanosecond
+	df := &fakeDFHack{}
+
+	for _, tt := range tests {
+		df.world, df.err = tt.world, tt.err
+		calls := df.calls
+
+		_, err := c.Intercept(context.Background(), listEnums, &dfproto.EmptyMessage{}, &dfproto.ListEnumsOut{}, df.invoke)
+		if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if cached := df.calls == calls; cached != tt.cached && tt.wantErr == nil {
+			t.Errorf("%s: cached = %v, want %v", tt.name, cached, tt.cached)
+		}
+		if n := c.Len(); n != tt.len {
+			t.Errorf("%s: %d replies cached, want %d", tt.name, n, tt.len)
+		}
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "respcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache")
+
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SaveDelay = time.Hour
+	df := &fakeDFHack{world: "region1"}
+
+	call := func(c *Cache) {
+		if _, err := c.Intercept(context.Background(), listEnums, &dfproto.EmptyMessage{}, &dfproto.ListEnumsOut{}, df.invoke); err != nil {
+			t.Fatal(err)
+		}
+	}
+	call(c)
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("saved before SaveDelay: %v", err)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 1 || loaded.world != "region1" {
+		t.Errorf("loaded %d replies from %q", loaded.Len(), loaded.world)
+	}
+
+	// Unchanged, so there is nothing to write.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("saved an unchanged cache: %v", err)
+	}
+
+	// A loaded cache is only used once the world is checked.
+	loaded.SaveDelay = 10 * time.Millisecond
+	calls := df.calls
+	call(loaded)
+	if df.calls != calls {
+		t.Error("a loaded reply wasn't used")
+	}
+
+	df.world = "region2"
+	loaded.CheckInterval = time.Nanosecond
+	call(loaded)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		saved, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saved.world == "region2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the cache was never saved after SaveDelay")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,39 @@
+package dfhack
+
+import (
+	"time"
+)
+
+// A StatsHandler is told about the calls a Conn makes, for instrumentation.
+// Its methods are called from the goroutine making the call, so they must
+// not block for long.
+type StatsHandler interface {
+	// HandleBind is called each time RoundTripBind looks up a method.
+	// cached reports whether the method was already bound.
+	HandleBind(method, plugin string, cached bool)
+
+	// HandleCall is called after every call, including BindMethod calls
+	// made by RoundTripBind.
+	HandleCall(s *CallStats)
+}
+
+// CallStats describes a single completed or failed call.
+type CallStats struct {
+	Method string
+	Plugin string // empty for core methods
+
+	Start time.Time
+	End   time.Time
+
+	// RequestSize and ResponseSize are the sizes of the encoded messages.
+	// ResponseSize is zero if the call failed.
+	RequestSize  int
+	ResponseSize int
+
+	// Text is the number of text notifications received.
+	Text int
+
+	// Err is the error returned by the call, if any. It is an *RPCError
+	// if DFHack reported the failure.
+	Err error
+}
@@ -0,0 +1,62 @@
+package dfhack
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrNoSubscriptions is returned by Subscribe if the server answered the
+// call but won't push updates to it. DFHack itself never does; only
+// armok_web does.
+var ErrNoSubscriptions = errors.New("dfhack: server does not support subscriptions")
+
+// A PushHandler receives the encoded reply to a subscribed method each time
+// the server pushes one. Handlers are called one at a time, in the order the
+// pushes arrived, on a goroutine of their own; a slow handler holds up later
+// pushes but not replies to calls. Pushes still waiting when the connection
+// is lost are dropped.
+type PushHandler func(result []byte)
+
+type subscriptionKey struct{}
+
+type subscription struct {
+	method string
+	plugin string
+	push   PushHandler // nil to unsubscribe
+}
+
+func pluginName(plugin *string) string {
+	if plugin == nil {
+		return ""
+	}
+	return *plugin
+}
+
+// Subscribe calls m like Call, and asks the server to push a new reply to h
+// whenever the reply to req changes. A Conn has at most one subscription per
+// method; subscribing again replaces the request.
+//
+// If the server doesn't support subscriptions, resp still holds the reply
+// and ErrNoSubscriptions is returned. Subscriptions end when the connection
+// is lost, so callers should subscribe again after reconnecting.
+func (c *Conn) Subscribe(ctx context.Context, m Method, req, resp proto.Message, h func(proto.Message)) ([]*dfproto.CoreTextNotification, error) {
+	push := func(b []byte) {
+		msg := m.NewOut()
+		if proto.Unmarshal(b, msg) == nil {
+			h(msg)
+		}
+	}
+
+	ctx = context.WithValue(ctx, subscriptionKey{}, &subscription{method: m.Name, plugin: m.Plugin, push: push})
+	return c.Call(ctx, m, req, resp)
+}
+
+// Unsubscribe calls m like Call and ends the Conn's subscription to it, if
+// any.
+func (c *Conn) Unsubscribe(ctx context.Context, m Method, req, resp proto.Message) ([]*dfproto.CoreTextNotification, error) {
+	ctx = context.WithValue(ctx, subscriptionKey{}, &subscription{method: m.Name, plugin: m.Plugin})
+	return c.Call(ctx, m, req, resp)
+}
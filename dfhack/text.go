@@ -0,0 +1,35 @@
+package dfhack
+
+import (
+	"context"
+	"strings"
+
+	"github.com/BenLubar/arm_ok/dfhack/dfproto"
+)
+
+// A TextHandler receives text notifications from a call as they arrive,
+// rather than all at once when the call returns. It is called from the
+// goroutine that reads replies, so it must not block for long or make calls
+// on the same Conn.
+type TextHandler func(*dfproto.CoreTextNotification)
+
+type textHandlerKey struct{}
+
+// WithTextHandler returns a context that streams the text notifications of
+// calls made with it to h. Text passed to a handler is not included in the
+// slice returned by the call or in the Text of an RPCError.
+//
+// This overrides the Dialer's TextHandler, if any.
+func WithTextHandler(ctx context.Context, h TextHandler) context.Context {
+	return context.WithValue(ctx, textHandlerKey{}, h)
+}
+
+func textString(text []*dfproto.CoreTextNotification) string {
+	var buf strings.Builder
+	for _, t := range text {
+		for _, f := range t.GetFragments() {
+			buf.WriteString(f.GetText())
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,89 @@
+// Command dfreplay serves a capture file recorded with armok_web -record
+// (or package capture) in place of DFHack, so armok_vision and armok_web can
+// run without Dwarf Fortress.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/BenLubar/arm_ok/dfhack/capture"
+)
+
+var flagAddr = flag.String("addr", "127.0.0.1:5000", "address to listen for DFHack connections on")
+var flagRealTime = flag.Bool("realtime", false, "delay replies by as long as they took when recorded")
+var flagUpstream = flag.String("upstream", "", "replay only the connections recorded to this DFHack address (required if the capture has more than one)")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: dfreplay [flags] capture-file\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("dfreplay: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 1 {
+		usage()
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	calls, err := capture.ReadFile(f)
+	f.Close()
+	if err != nil {
+		log.Fatalln("reading capture:", err)
+	}
+
+	if calls, err = filterCalls(calls, *flagUpstream); err != nil {
+		log.Fatalln(err)
+	}
+
+	l, err := net.Listen("tcp", *flagAddr)
+	if err != nil {
+		log.Fatalln("listening failed:", err)
+	}
+
+	log.Printf("replaying %d calls on %v", len(calls), l.Addr())
+
+	log.Fatalln(capture.NewServer(calls, *flagRealTime).Serve(l))
+}
+
+// filterCalls returns the calls made to the DFHack at addr. If addr is
+// empty, the capture must only have calls to one DFHack, such as one
+// recorded by an armok_web serving a single fortress.
+func filterCalls(calls []*capture.Call, addr string) ([]*capture.Call, error) {
+	if addr == "" {
+		var addrs []string
+		seen := make(map[string]bool)
+		for _, c := range calls {
+			if !seen[c.Addr] {
+				seen[c.Addr] = true
+				addrs = append(addrs, c.Addr)
+			}
+		}
+		if len(addrs) > 1 {
+			return nil, fmt.Errorf("capture has connections to %d DFHack addresses; choose one with -upstream: %s", len(addrs), strings.Join(addrs, ", "))
+		}
+		return calls, nil
+	}
+
+	var filtered []*capture.Call
+	for _, c := range calls {
+		if c.Addr == addr {
+			filtered = append(filtered, c)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("capture has no connections to %s", addr)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/BenLubar/arm_ok/dfhack/capture"
+)
+
+func TestFilterCalls(t *testing.T) {
+	one := []*capture.Call{
+		{Conn: 0, Addr: "127.0.0.1:5000", Method: "GetVersion"},
+		{Conn: 1, Addr: "127.0.0.1:5000", Method: "GetVersion"},
+	}
+	two := append([]*capture.Call{
+		{Conn: 2, Addr: "10.0.0.2:5000", Method: "GetDFVersion"},
+	}, one...)
+
+	tests := []struct {
+		calls []*capture.Call
+		addr  string
+		want  int // -1 for an error
+	}{
+		{one, "", 2},
+		{one, "127.0.0.1:5000", 2},
+		{one, "10.0.0.2:5000", -1},
+		{two, "", -1},
+		{two, "10.0.0.2:5000", 1},
+		{two, "127.0.0.1:5000", 2},
+	}
+
+	for _, tt := range tests {
+		calls, err := filterCalls(tt.calls, tt.addr)
+		if tt.want < 0 {
+			if err == nil {
+				t.Errorf("%d calls, %q: got %d calls, want an error", len(tt.calls), tt.addr, len(calls))
+			}
+			continue
+		}
+		if err != nil || len(calls) != tt.want {
+			t.Errorf("%d calls, %q: got %d calls, %v, want %d", len(tt.calls), tt.addr, len(calls), err, tt.want)
+		}
+	}
+}